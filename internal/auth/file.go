@@ -1,28 +1,58 @@
 package auth
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
+
+	"github.com/christophergyman/claude-quick/internal/util"
 )
 
-// CredFileName is the name of the credential file written to project directories.
-const CredFileName = ".claude-quick-auth"
+// LegacyCredFileName is the credential file older versions wrote into project
+// directories. It is removed whenever credentials are written or cleaned up.
+const LegacyCredFileName = ".claude-quick-auth"
+
+// CredentialDir returns the host directory credential files are kept in, outside
+// any workspace: under $XDG_RUNTIME_DIR (a per-user tmpfs on Linux) when set,
+// otherwise in a per-user directory under the system temp dir. The fallback is
+// usually on disk (on macOS, where XDG_RUNTIME_DIR is rarely set, it is the
+// per-user $TMPDIR), so files there rely on being deleted rather than on
+// vanishing at reboot.
+func CredentialDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "claude-quick", "auth")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("claude-quick-%d", os.Getuid()), "auth")
+}
+
+// CredentialFilePath returns the path to the credential file for a project's container.
+// The file is named after the project directory plus a hash of its full path, so
+// worktrees with the same directory name don't collide.
+func CredentialFilePath(projectPath string) string {
+	return filepath.Join(CredentialDir(), util.PathKey(projectPath)+".env")
+}
 
-// WriteCredentialFile writes the resolved credentials to a file in the project directory.
-// The file is written with restricted permissions (0600) and can be sourced by shells.
+// WriteCredentialFile writes the resolved credentials for a project's container.
+// The file is written with restricted permissions (0600) in a private directory
+// outside the project, so it is neither visible to the agent nor committable.
 func WriteCredentialFile(projectPath string, creds map[string]string) error {
+	removeLegacyCredentialFile(projectPath)
 	if len(creds) == 0 {
 		return nil
 	}
 
-	filePath := filepath.Join(projectPath, CredFileName)
+	filePath := CredentialFilePath(projectPath)
+	dir := filepath.Dir(filePath)
+	if err := ensurePrivateDir(dir); err != nil {
+		return err
+	}
 
 	var buf strings.Builder
-	buf.WriteString("# Generated by claude-quick - DO NOT COMMIT\n")
-	buf.WriteString("# This file contains authentication credentials\n")
-	buf.WriteString("# Source this file: source .claude-quick-auth\n\n")
+	buf.WriteString("# Generated by claude-quick - removed when the container stops\n")
+	buf.WriteString("# This file contains authentication credentials\n\n")
 
 	for name, value := range creds {
 		// Escape single quotes in value by ending the quote, adding escaped quote, and starting new quote
@@ -30,24 +60,156 @@ func WriteCredentialFile(projectPath string, creds map[string]string) error {
 		buf.WriteString(fmt.Sprintf("export %s='%s'\n", name, escaped))
 	}
 
-	// Write with restricted permissions (600 = owner read/write only)
-	if err := os.WriteFile(filePath, []byte(buf.String()), 0600); err != nil {
+	// Write a fresh file with restricted permissions (600 = owner read/write only)
+	// and rename it into place, so an existing file or symlink at filePath is
+	// replaced rather than written through
+	tmp, err := os.CreateTemp(dir, ".write-*")
+	if err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.WriteString(buf.String()); err != nil {
+		tmp.Close()
 		return fmt.Errorf("failed to write credential file: %w", err)
 	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+
+	return nil
+}
+
+// ensurePrivateDir creates dir if needed and checks that only the current user
+// can access it and its parent (the per-user claude-quick directory), since the
+// temp dir fallback is shared with other users who could create them first
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create credential directory: %w", err)
+	}
+	for _, d := range []string{filepath.Dir(dir), dir} {
+		if err := checkPrivateDir(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// checkPrivateDir checks that dir is a real directory owned by the current user
+// with no access for anyone else
+func checkPrivateDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to check credential directory: %w", err)
+	}
+	if !info.IsDir() || info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("credential directory %s must be a directory only you can access (mode 0700)", dir)
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok && int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("credential directory %s is owned by another user", dir)
+	}
 	return nil
 }
 
-// CleanupCredentialFile removes the credential file from a project directory.
+// ReadCredentialFile parses a project's credential file and returns env var
+// name/value pairs (empty if there is none)
+func ReadCredentialFile(projectPath string) map[string]string {
+	result := make(map[string]string)
+
+	file, err := os.Open(CredentialFilePath(projectPath))
+	if err != nil {
+		return result // File doesn't exist or can't be read
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip comments and empty lines
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Parse "export NAME='value'" format
+		if strings.HasPrefix(line, "export ") {
+			line = strings.TrimPrefix(line, "export ")
+			if idx := strings.Index(line, "="); idx > 0 {
+				name := line[:idx]
+				value := line[idx+1:]
+
+				// Remove only the outermost quotes (not all leading/trailing quote chars)
+				// strings.Trim would remove ALL matching chars, corrupting escaped values
+				if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+					value = value[1 : len(value)-1]
+				}
+
+				// Handle escaped single quotes: 'val'"'"'ue' -> val'ue
+				// The pattern '\"'\"' is shell escaping for a literal single quote
+				value = strings.ReplaceAll(value, "'\"'\"'", "'")
+
+				result[name] = value
+			}
+		}
+	}
+
+	return result
+}
+
+// CleanupCredentialFile removes the credential file for a project's container
+// (and any legacy file in the project directory).
 func CleanupCredentialFile(projectPath string) error {
-	filePath := filepath.Join(projectPath, CredFileName)
-	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+	removeLegacyCredentialFile(projectPath)
+	if err := os.Remove(CredentialFilePath(projectPath)); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove credential file: %w", err)
 	}
 	return nil
 }
 
-// CredentialFilePath returns the path to the credential file for a project.
-func CredentialFilePath(projectPath string) string {
-	return filepath.Join(projectPath, CredFileName)
+// SweepCredentialFiles removes the credential files of every project except those
+// in keep (the projects whose containers are running), e.g. files left behind by a
+// crash or by containers stopped outside claude-quick. Returns how many were removed.
+func SweepCredentialFiles(keep []string) (int, error) {
+	keepNames := make(map[string]bool, len(keep))
+	for _, path := range keep {
+		keepNames[filepath.Base(CredentialFilePath(path))] = true
+	}
+
+	entries, err := os.ReadDir(CredentialDir())
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read credential directory: %w", err)
+	}
+	removed := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".env") || keepNames[name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(CredentialDir(), name)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove credential file: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// MoveCredentialFile carries a project's credential file over to its new path
+// when the project directory is moved (nothing to do if there is none)
+func MoveCredentialFile(oldPath, newPath string) error {
+	err := os.Rename(CredentialFilePath(oldPath), CredentialFilePath(newPath))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to move credential file: %w", err)
+	}
+	return nil
+}
+
+// removeLegacyCredentialFile removes a credential file left in the project
+// directory by an older version
+func removeLegacyCredentialFile(projectPath string) {
+	_ = os.Remove(filepath.Join(projectPath, LegacyCredFileName))
 }
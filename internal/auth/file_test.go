@@ -8,43 +8,33 @@ import (
 )
 
 func TestCredentialFilePath(t *testing.T) {
-	tests := []struct {
-		name        string
-		projectPath string
-		expected    string
-	}{
-		{
-			name:        "simple path",
-			projectPath: "/home/user/project",
-			expected:    "/home/user/project/" + CredFileName,
-		},
-		{
-			name:        "with trailing slash",
-			projectPath: "/home/user/project/",
-			expected:    "/home/user/project/" + CredFileName,
-		},
-		{
-			name:        "relative path",
-			projectPath: "project",
-			expected:    "project/" + CredFileName,
-		},
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+
+	path := CredentialFilePath("/home/user/project")
+	if filepath.Dir(path) != filepath.Join(runtimeDir, "claude-quick", "auth") {
+		t.Errorf("CredentialFilePath() = %q, want it under $XDG_RUNTIME_DIR", path)
+	}
+	if !strings.HasPrefix(filepath.Base(path), "project-") || !strings.HasSuffix(path, ".env") {
+		t.Errorf("CredentialFilePath() = %q, want project-<hash>.env", path)
+	}
+	if got := CredentialFilePath("/home/user/project/"); got != path {
+		t.Errorf("CredentialFilePath() with trailing slash = %q, want %q", got, path)
+	}
+	if other := CredentialFilePath("/home/user/wt/project"); other == path {
+		t.Error("CredentialFilePath() is the same for different projects with the same name")
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := CredentialFilePath(tt.projectPath)
-			// Use filepath.Clean to normalize for comparison
-			if filepath.Clean(result) != filepath.Clean(tt.expected) {
-				t.Errorf("CredentialFilePath(%q) = %q, want %q", tt.projectPath, result, tt.expected)
-			}
-		})
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if path := CredentialFilePath("/home/user/project"); !strings.HasPrefix(path, os.TempDir()) {
+		t.Errorf("CredentialFilePath() without $XDG_RUNTIME_DIR = %q, want it under %s", path, os.TempDir())
 	}
 }
 
-func TestCredFileName(t *testing.T) {
+func TestLegacyCredFileName(t *testing.T) {
 	// Verify the constant value
-	if CredFileName != ".claude-quick-auth" {
-		t.Errorf("CredFileName = %q, want %q", CredFileName, ".claude-quick-auth")
+	if LegacyCredFileName != ".claude-quick-auth" {
+		t.Errorf("LegacyCredFileName = %q, want %q", LegacyCredFileName, ".claude-quick-auth")
 	}
 }
 
@@ -54,6 +44,7 @@ func TestWriteCredentialFile_Empty(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
 	// Empty credentials should not create a file
 	err = WriteCredentialFile(tmpDir, map[string]string{})
@@ -61,7 +52,7 @@ func TestWriteCredentialFile_Empty(t *testing.T) {
 		t.Errorf("WriteCredentialFile() with empty creds returned error: %v", err)
 	}
 
-	filePath := filepath.Join(tmpDir, CredFileName)
+	filePath := CredentialFilePath(tmpDir)
 	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
 		t.Error("WriteCredentialFile() with empty creds created a file, but shouldn't")
 	}
@@ -73,6 +64,7 @@ func TestWriteCredentialFile_SingleCredential(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
 	creds := map[string]string{
 		"API_KEY": "secret-value-123",
@@ -83,7 +75,7 @@ func TestWriteCredentialFile_SingleCredential(t *testing.T) {
 		t.Fatalf("WriteCredentialFile() returned error: %v", err)
 	}
 
-	filePath := filepath.Join(tmpDir, CredFileName)
+	filePath := CredentialFilePath(tmpDir)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		t.Fatalf("failed to read credential file: %v", err)
@@ -108,6 +100,7 @@ func TestWriteCredentialFile_MultipleCredentials(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
 	creds := map[string]string{
 		"API_KEY":      "secret1",
@@ -119,7 +112,7 @@ func TestWriteCredentialFile_MultipleCredentials(t *testing.T) {
 		t.Fatalf("WriteCredentialFile() returned error: %v", err)
 	}
 
-	filePath := filepath.Join(tmpDir, CredFileName)
+	filePath := CredentialFilePath(tmpDir)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		t.Fatalf("failed to read credential file: %v", err)
@@ -142,6 +135,7 @@ func TestWriteCredentialFile_QuoteEscaping(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
 	tests := []struct {
 		name     string
@@ -178,7 +172,7 @@ func TestWriteCredentialFile_QuoteEscaping(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Clean up from previous test
-			os.Remove(filepath.Join(tmpDir, CredFileName))
+			os.Remove(CredentialFilePath(tmpDir))
 
 			creds := map[string]string{"TEST": tt.value}
 			err := WriteCredentialFile(tmpDir, creds)
@@ -186,7 +180,7 @@ func TestWriteCredentialFile_QuoteEscaping(t *testing.T) {
 				t.Fatalf("WriteCredentialFile() returned error: %v", err)
 			}
 
-			filePath := filepath.Join(tmpDir, CredFileName)
+			filePath := CredentialFilePath(tmpDir)
 			content, err := os.ReadFile(filePath)
 			if err != nil {
 				t.Fatalf("failed to read credential file: %v", err)
@@ -205,6 +199,7 @@ func TestWriteCredentialFile_Permissions(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
 	creds := map[string]string{"TEST": "value"}
 	err = WriteCredentialFile(tmpDir, creds)
@@ -212,7 +207,7 @@ func TestWriteCredentialFile_Permissions(t *testing.T) {
 		t.Fatalf("WriteCredentialFile() returned error: %v", err)
 	}
 
-	filePath := filepath.Join(tmpDir, CredFileName)
+	filePath := CredentialFilePath(tmpDir)
 	info, err := os.Stat(filePath)
 	if err != nil {
 		t.Fatalf("failed to stat credential file: %v", err)
@@ -231,10 +226,15 @@ func TestCleanupCredentialFile_Exists(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
-	// Create the file first
-	filePath := filepath.Join(tmpDir, CredFileName)
-	if err := os.WriteFile(filePath, []byte("test"), 0600); err != nil {
+	// Create the file (and a legacy one in the project) first
+	filePath := CredentialFilePath(tmpDir)
+	if err := WriteCredentialFile(tmpDir, map[string]string{"TEST": "value"}); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	legacyPath := filepath.Join(tmpDir, LegacyCredFileName)
+	if err := os.WriteFile(legacyPath, []byte("test"), 0600); err != nil {
 		t.Fatalf("failed to create test file: %v", err)
 	}
 
@@ -248,6 +248,9 @@ func TestCleanupCredentialFile_Exists(t *testing.T) {
 	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
 		t.Error("CleanupCredentialFile() did not remove the file")
 	}
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Error("CleanupCredentialFile() did not remove the legacy file")
+	}
 }
 
 func TestCleanupCredentialFile_NotExists(t *testing.T) {
@@ -256,6 +259,7 @@ func TestCleanupCredentialFile_NotExists(t *testing.T) {
 		t.Fatalf("failed to create temp dir: %v", err)
 	}
 	defer os.RemoveAll(tmpDir)
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
 
 	// File doesn't exist - should not return error
 	err = CleanupCredentialFile(tmpDir)
@@ -264,10 +268,173 @@ func TestCleanupCredentialFile_NotExists(t *testing.T) {
 	}
 }
 
-func TestWriteCredentialFile_NonexistentDir(t *testing.T) {
-	// Try to write to a non-existent directory
-	err := WriteCredentialFile("/nonexistent/path/that/does/not/exist", map[string]string{"TEST": "value"})
-	if err == nil {
-		t.Error("WriteCredentialFile() should return error for non-existent directory")
+func TestWriteCredentialFile_OutsideProject(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	projectDir := t.TempDir()
+	legacyPath := filepath.Join(projectDir, LegacyCredFileName)
+	if err := os.WriteFile(legacyPath, []byte("export OLD='secret'\n"), 0600); err != nil {
+		t.Fatalf("failed to create legacy file: %v", err)
+	}
+
+	if err := WriteCredentialFile(projectDir, map[string]string{"TEST": "value"}); err != nil {
+		t.Fatalf("WriteCredentialFile() returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("project directory has %d entries, want none (legacy file removed, nothing written)", len(entries))
+	}
+	info, err := os.Stat(filepath.Dir(CredentialFilePath(projectDir)))
+	if err != nil {
+		t.Fatalf("credential directory missing: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0700 {
+		t.Errorf("credential directory permissions = %o, want 700", perm)
+	}
+}
+
+func TestWriteCredentialFile_SharedDirRejected(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	dir := filepath.Join(runtimeDir, "claude-quick", "auth")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteCredentialFile(t.TempDir(), map[string]string{"TEST": "value"}); err == nil {
+		t.Error("WriteCredentialFile() into a world-accessible directory returned nil error")
+	}
+}
+
+func TestWriteCredentialFile_SharedRootRejected(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	root := filepath.Join(runtimeDir, "claude-quick")
+	if err := os.MkdirAll(filepath.Join(root, "auth"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	// Whoever controls the parent can swap out the private auth directory
+	if err := os.Chmod(root, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteCredentialFile(t.TempDir(), map[string]string{"TEST": "value"}); err == nil {
+		t.Error("WriteCredentialFile() under a world-accessible root returned nil error")
+	}
+}
+
+func TestWriteCredentialFile_ReplacesSymlink(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(CredentialDir(), 0700); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(t.TempDir(), "target")
+	if err := os.WriteFile(target, []byte("untouched"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, CredentialFilePath(projectDir)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteCredentialFile(projectDir, map[string]string{"TEST": "value"}); err != nil {
+		t.Fatalf("WriteCredentialFile() returned error: %v", err)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "untouched" {
+		t.Errorf("WriteCredentialFile() wrote through a symlink: target = %q", data)
+	}
+	info, err := os.Lstat(CredentialFilePath(projectDir))
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm() != 0600 {
+		t.Errorf("credential file = %v, %v; want a regular 0600 file", info, err)
+	}
+}
+
+func TestReadCredentialFile(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	projectDir := t.TempDir()
+
+	if got := ReadCredentialFile(projectDir); len(got) != 0 {
+		t.Errorf("ReadCredentialFile() without a file = %v, want empty", got)
+	}
+
+	creds := map[string]string{
+		"API_KEY": "secret1",
+		"QUOTED":  "it's a 'test'",
+		"EQUALS":  "a=b",
+	}
+	if err := WriteCredentialFile(projectDir, creds); err != nil {
+		t.Fatalf("WriteCredentialFile() returned error: %v", err)
+	}
+	got := ReadCredentialFile(projectDir)
+	if len(got) != len(creds) {
+		t.Fatalf("ReadCredentialFile() = %v, want %v", got, creds)
+	}
+	for name, value := range creds {
+		if got[name] != value {
+			t.Errorf("ReadCredentialFile()[%s] = %q, want %q", name, got[name], value)
+		}
+	}
+}
+
+func TestMoveCredentialFile(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	// Nothing to move
+	if err := MoveCredentialFile("/work/app-old", "/work/app-new"); err != nil {
+		t.Errorf("MoveCredentialFile() without a file returned error: %v", err)
+	}
+
+	if err := WriteCredentialFile("/work/app-old", map[string]string{"TEST": "value"}); err != nil {
+		t.Fatalf("WriteCredentialFile() returned error: %v", err)
+	}
+	if err := MoveCredentialFile("/work/app-old", "/work/app-new"); err != nil {
+		t.Fatalf("MoveCredentialFile() returned error: %v", err)
+	}
+	if got := ReadCredentialFile("/work/app-new"); got["TEST"] != "value" {
+		t.Errorf("credentials at new path = %v, want TEST=value", got)
+	}
+	if _, err := os.Stat(CredentialFilePath("/work/app-old")); !os.IsNotExist(err) {
+		t.Error("MoveCredentialFile() left the old file behind")
+	}
+}
+
+func TestSweepCredentialFiles(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	// Nothing written yet
+	if removed, err := SweepCredentialFiles(nil); err != nil || removed != 0 {
+		t.Errorf("SweepCredentialFiles() without a directory = %d, %v; want 0, nil", removed, err)
+	}
+
+	for _, path := range []string{"/work/running", "/work/stopped", "/work/crashed"} {
+		if err := WriteCredentialFile(path, map[string]string{"TEST": "value"}); err != nil {
+			t.Fatalf("WriteCredentialFile(%s) returned error: %v", path, err)
+		}
+	}
+	other := filepath.Join(CredentialDir(), "notes.txt")
+	if err := os.WriteFile(other, []byte("keep"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := SweepCredentialFiles([]string{"/work/running"})
+	if err != nil || removed != 2 {
+		t.Fatalf("SweepCredentialFiles() = %d, %v; want 2, nil", removed, err)
+	}
+	if got := ReadCredentialFile("/work/running"); got["TEST"] != "value" {
+		t.Error("SweepCredentialFiles() removed the running project's credentials")
+	}
+	for _, path := range []string{"/work/stopped", "/work/crashed"} {
+		if _, err := os.Stat(CredentialFilePath(path)); !os.IsNotExist(err) {
+			t.Errorf("SweepCredentialFiles() kept the credentials of %s", path)
+		}
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Error("SweepCredentialFiles() removed a file that isn't a credential file")
 	}
 }
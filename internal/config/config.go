@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 
 	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/hosttmux"
+	"github.com/christophergyman/claude-quick/internal/notify"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 	"github.com/christophergyman/claude-quick/internal/util"
 	"gopkg.in/yaml.v3"
 )
@@ -38,8 +44,51 @@ type Config struct {
 	LaunchCommand      string        `yaml:"launch_command,omitempty"`
 	DarkMode           *bool         `yaml:"dark_mode,omitempty"`
 	AutoPushWorktree   *bool         `yaml:"auto_push_worktree,omitempty"`
+	ObserveIgnoreSize  *bool         `yaml:"observe_ignore_size,omitempty"`
 	Auth               auth.Config   `yaml:"auth,omitempty"`
 	GitHub             github.Config `yaml:"github,omitempty"`
+
+	// Worktree holds the global worktree settings (worktree_copy, worktree_post_create, ...)
+	Worktree devcontainer.WorktreeConfig `yaml:",inline"`
+	// Projects holds per-project overrides keyed by project name
+	Projects map[string]ProjectConfig `yaml:"projects,omitempty"`
+
+	// TmuxTemplates holds named window/pane layouts for new tmux sessions
+	TmuxTemplates map[string]tmux.Template `yaml:"tmux_templates,omitempty"`
+	// DefaultTmuxTemplate is preselected when creating a session (empty for a single window)
+	DefaultTmuxTemplate string `yaml:"default_tmux_template,omitempty"`
+
+	// TmuxTheme styles new sessions: "anthropic" (default), "none" or a name in TmuxThemes
+	TmuxTheme string `yaml:"tmux_theme,omitempty"`
+	// TmuxThemes holds user-defined session themes
+	TmuxThemes map[string]tmux.Theme `yaml:"tmux_themes,omitempty"`
+	// TmuxConf is a host tmux.conf snippet sourced in the container when a session is created
+	TmuxConf string `yaml:"tmux_conf,omitempty"`
+	// TmuxConfLight replaces TmuxConf in light mode
+	TmuxConfLight string `yaml:"tmux_conf_light,omitempty"`
+
+	// Notify lists the agent transitions that notify ("waiting", "finished"); unset means both
+	Notify []string `yaml:"notify,omitempty"`
+	// NotifyMethod selects how notifications are delivered (auto, bell, osc9, osc777, notify-send, none)
+	NotifyMethod string `yaml:"notify_method,omitempty"`
+
+	// HostTmux selects how attaching works when claude-quick runs inside host tmux:
+	// "window" (default) or "pane" opens the attach in host tmux, "off" attaches in place
+	HostTmux string `yaml:"host_tmux,omitempty"`
+
+	// TranscriptDir is the host directory session scrollback is exported to (default constants.DefaultTranscriptDir)
+	TranscriptDir string `yaml:"transcript_dir,omitempty"`
+	// ArchiveTranscripts exports scrollback automatically before a session is killed or its container stops
+	ArchiveTranscripts bool `yaml:"archive_transcripts,omitempty"`
+}
+
+// ProjectConfig holds settings that can be overridden for a single project
+type ProjectConfig struct {
+	Worktree devcontainer.WorktreeConfig `yaml:",inline"`
+	// TmuxTemplate overrides default_tmux_template for the project
+	TmuxTemplate string `yaml:"tmux_template,omitempty"`
+	// Notify overrides the global notify transitions for the project (empty list disables them)
+	Notify []string `yaml:"notify,omitempty"`
 }
 
 // DefaultExcludedDirs returns the default directories to exclude from scanning
@@ -149,6 +198,15 @@ func Load() (*Config, error) {
 	for i, p := range cfg.SearchPaths {
 		cfg.SearchPaths[i] = util.ExpandPath(p)
 	}
+	if cfg.TranscriptDir != "" {
+		cfg.TranscriptDir = util.ExpandPath(cfg.TranscriptDir)
+	}
+	if cfg.TmuxConf != "" {
+		cfg.TmuxConf = util.ExpandPath(cfg.TmuxConf)
+	}
+	if cfg.TmuxConfLight != "" {
+		cfg.TmuxConfLight = util.ExpandPath(cfg.TmuxConfLight)
+	}
 
 	// Ensure reasonable defaults
 	if cfg.MaxDepth <= 0 {
@@ -179,6 +237,36 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// Validate worktree configuration
+	if err := cfg.Worktree.Validate(); err != nil {
+		return nil, err
+	}
+	for name, proj := range cfg.Projects {
+		if err := proj.Worktree.Validate(); err != nil {
+			return nil, fmt.Errorf("projects.%s: %w", name, err)
+		}
+	}
+
+	// Validate tmux session templates
+	if err := cfg.validateTmuxTemplates(); err != nil {
+		return nil, err
+	}
+
+	// Validate tmux session theme
+	if err := cfg.validateTmuxTheme(); err != nil {
+		return nil, err
+	}
+
+	// Validate notification settings
+	if err := cfg.validateNotify(); err != nil {
+		return nil, err
+	}
+
+	// Validate host tmux integration
+	if err := hosttmux.ValidateMode(cfg.HostTmux); err != nil {
+		return nil, err
+	}
+
 	// Ensure GitHub config has sensible defaults
 	if cfg.GitHub.MaxIssues <= 0 {
 		cfg.GitHub.MaxIssues = constants.DefaultMaxIssues
@@ -237,6 +325,172 @@ func (c *Config) IsAutoPushWorktree() bool {
 	return *c.AutoPushWorktree
 }
 
+// IsObserveIgnoreSize returns whether observing a session leaves its window size alone
+func (c *Config) IsObserveIgnoreSize() bool {
+	if c.ObserveIgnoreSize == nil {
+		return false // Opt-in: attach -f read-only,ignore-size needs tmux 3.2+ in the container
+	}
+	return *c.ObserveIgnoreSize
+}
+
+// WorktreeConfigFor returns the worktree settings for a project,
+// with project-specific values taking precedence over the global ones
+func (c *Config) WorktreeConfigFor(projectName string) devcontainer.WorktreeConfig {
+	if proj, ok := c.Projects[projectName]; ok {
+		return c.Worktree.Merge(proj.Worktree)
+	}
+	return c.Worktree
+}
+
+// validateTmuxTemplates checks each template and that default templates exist
+func (c *Config) validateTmuxTemplates() error {
+	for name, tmpl := range c.TmuxTemplates {
+		if err := tmpl.Validate(); err != nil {
+			return fmt.Errorf("tmux_templates.%s: %w", name, err)
+		}
+	}
+	if _, ok := c.TmuxTemplates[c.DefaultTmuxTemplate]; c.DefaultTmuxTemplate != "" && !ok {
+		return fmt.Errorf("default_tmux_template: unknown template %q", c.DefaultTmuxTemplate)
+	}
+	for name, proj := range c.Projects {
+		if _, ok := c.TmuxTemplates[proj.TmuxTemplate]; proj.TmuxTemplate != "" && !ok {
+			return fmt.Errorf("projects.%s.tmux_template: unknown template %q", name, proj.TmuxTemplate)
+		}
+	}
+	return nil
+}
+
+// TmuxTemplateNames returns the configured session template names in sorted order
+func (c *Config) TmuxTemplateNames() []string {
+	names := make([]string, 0, len(c.TmuxTemplates))
+	for name := range c.TmuxTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TmuxTemplate returns the named session template, or nil if it is not configured
+func (c *Config) TmuxTemplate(name string) *tmux.Template {
+	tmpl, ok := c.TmuxTemplates[name]
+	if !ok {
+		return nil
+	}
+	tmpl.Name = name
+	return &tmpl
+}
+
+// TmuxTemplateFor returns the name of the template preselected for a project's
+// new sessions, with the project setting taking precedence over the global one
+func (c *Config) TmuxTemplateFor(projectName string) string {
+	if proj, ok := c.Projects[projectName]; ok && proj.TmuxTemplate != "" {
+		return proj.TmuxTemplate
+	}
+	return c.DefaultTmuxTemplate
+}
+
+// validateTmuxTheme checks the user-defined themes and that the selected theme exists
+func (c *Config) validateTmuxTheme() error {
+	for name, theme := range c.TmuxThemes {
+		if name == tmux.ThemeNone {
+			return fmt.Errorf("tmux_themes.%s: %q is reserved for no styling", name, tmux.ThemeNone)
+		}
+		if err := theme.Validate(); err != nil {
+			return fmt.Errorf("tmux_themes.%s: %w", name, err)
+		}
+	}
+	if _, ok := c.tmuxTheme(); !ok {
+		return fmt.Errorf("tmux_theme: unknown theme %q", c.TmuxTheme)
+	}
+	return nil
+}
+
+// tmuxTheme returns the selected session theme, preferring user-defined themes
+// over built-in ones of the same name
+func (c *Config) tmuxTheme() (tmux.Theme, bool) {
+	name := c.TmuxTheme
+	if name == "" {
+		name = tmux.ThemeAnthropic
+	}
+	if theme, ok := c.TmuxThemes[name]; ok {
+		return theme, true
+	}
+	return tmux.BuiltinTheme(name)
+}
+
+// TmuxStyle returns how new sessions are styled in dark or light mode: the selected
+// theme's options plus the tmux_conf snippet (tmux_conf_light in light mode, if set),
+// read from the host
+func (c *Config) TmuxStyle(dark bool) (tmux.Style, error) {
+	theme, _ := c.tmuxTheme()
+	style := tmux.Style{Options: theme.Variant(dark)}
+
+	path := c.TmuxConf
+	if !dark && c.TmuxConfLight != "" {
+		path = c.TmuxConfLight
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return style, fmt.Errorf("failed to read tmux_conf: %w", err)
+		}
+		style.Conf = string(data)
+	}
+	return style, nil
+}
+
+// validateNotify checks the notification method and the configured transitions
+func (c *Config) validateNotify() error {
+	if err := notify.ValidateMethod(c.NotifyMethod); err != nil {
+		return err
+	}
+	if err := notify.ValidateEvents(c.Notify); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+	for name, proj := range c.Projects {
+		if err := notify.ValidateEvents(proj.Notify); err != nil {
+			return fmt.Errorf("projects.%s.notify: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// NotifiesOn returns whether an agent transition in a project raises a notification,
+// with the project setting taking precedence over the global one
+func (c *Config) NotifiesOn(projectName, event string) bool {
+	events := notify.DefaultEvents()
+	if proj, ok := c.Projects[projectName]; ok && proj.Notify != nil {
+		events = proj.Notify
+	} else if c.Notify != nil {
+		events = c.Notify
+	}
+	return slices.Contains(events, event)
+}
+
+// TranscriptRoot returns the host directory session transcripts are written under
+func (c *Config) TranscriptRoot() string {
+	if c.TranscriptDir == "" {
+		return util.ExpandPath(constants.DefaultTranscriptDir)
+	}
+	return c.TranscriptDir
+}
+
+// TranscriptDirFor returns the host directory an instance's session transcripts are
+// written to. It is named after the project directory plus a hash of its full path,
+// since every worktree of a repository shares the instance name.
+func (c *Config) TranscriptDirFor(projectPath string) string {
+	return filepath.Join(c.TranscriptRoot(), util.PathKey(projectPath))
+}
+
+// ArchiveDirFor returns the directory scrollback is archived to before an instance's
+// sessions are killed or its container stops, or "" if archiving is off
+func (c *Config) ArchiveDirFor(projectPath string) string {
+	if !c.ArchiveTranscripts {
+		return ""
+	}
+	return c.TranscriptDirFor(projectPath)
+}
+
 // ConfigExists returns true if a config file exists (either new or legacy location)
 func ConfigExists() bool {
 	_, source := configPath()
@@ -3,9 +3,13 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+	"github.com/christophergyman/claude-quick/internal/util"
+	"gopkg.in/yaml.v3"
 )
 
 func TestDefaultExcludedDirs(t *testing.T) {
@@ -56,6 +60,16 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_IsObserveIgnoreSize(t *testing.T) {
+	// Off unless enabled, since it needs tmux 3.2+ in the container
+	if (&Config{}).IsObserveIgnoreSize() {
+		t.Error("IsObserveIgnoreSize() = true by default, want false")
+	}
+	if !(&Config{ObserveIgnoreSize: boolPtr(true)}).IsObserveIgnoreSize() {
+		t.Error("IsObserveIgnoreSize() = false when enabled, want true")
+	}
+}
+
 func TestConfig_IsDarkMode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -296,3 +310,221 @@ func TestIsUsingLegacyConfig(t *testing.T) {
 	isLegacy := IsUsingLegacyConfig()
 	t.Logf("IsUsingLegacyConfig() = %v", isLegacy)
 }
+
+func TestConfig_WorktreeConfigFor(t *testing.T) {
+	data := []byte(`
+worktree_copy:
+  - .env
+worktree_copy_mode: symlink
+projects:
+  web:
+    worktree_copy:
+      - .env.local
+      - certs/*.pem
+    worktree_post_create:
+      - npm ci
+`)
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	global := cfg.WorktreeConfigFor("api")
+	if len(global.Copy) != 1 || global.Copy[0] != ".env" {
+		t.Errorf("global Copy = %v, want [.env]", global.Copy)
+	}
+	if len(global.PostCreate) != 0 {
+		t.Errorf("global PostCreate = %v, want empty", global.PostCreate)
+	}
+
+	project := cfg.WorktreeConfigFor("web")
+	if len(project.Copy) != 2 || project.Copy[0] != ".env.local" {
+		t.Errorf("project Copy = %v, want [.env.local certs/*.pem]", project.Copy)
+	}
+	if project.CopyMode != "symlink" {
+		t.Errorf("project CopyMode = %q, want inherited %q", project.CopyMode, "symlink")
+	}
+	if len(project.PostCreate) != 1 || project.PostCreate[0] != "npm ci" {
+		t.Errorf("project PostCreate = %v, want [npm ci]", project.PostCreate)
+	}
+}
+
+func TestConfig_TmuxTemplates(t *testing.T) {
+	data := []byte(`
+default_tmux_template: agent
+tmux_templates:
+  agent:
+    windows:
+      - name: agent
+        panes:
+          - launch: true
+            focus: true
+          - split: right
+            size: 40%
+            command: npm test -- --watch
+      - name: shell
+  review:
+    windows:
+      - name: diff
+projects:
+  docs:
+    tmux_template: review
+`)
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	if err := cfg.validateTmuxTemplates(); err != nil {
+		t.Fatalf("validateTmuxTemplates() error: %v", err)
+	}
+
+	if got := cfg.TmuxTemplateNames(); len(got) != 2 || got[0] != "agent" || got[1] != "review" {
+		t.Errorf("TmuxTemplateNames() = %v, want [agent review]", got)
+	}
+	if got := cfg.TmuxTemplateFor("api"); got != "agent" {
+		t.Errorf("TmuxTemplateFor(api) = %q, want global default", got)
+	}
+	if got := cfg.TmuxTemplateFor("docs"); got != "review" {
+		t.Errorf("TmuxTemplateFor(docs) = %q, want project template", got)
+	}
+
+	tmpl := cfg.TmuxTemplate("agent")
+	if tmpl == nil || tmpl.Name != "agent" || len(tmpl.Windows) != 2 || len(tmpl.Windows[0].Panes) != 2 {
+		t.Fatalf("TmuxTemplate(agent) = %+v, want named template with 2 windows", tmpl)
+	}
+	if !tmpl.Windows[0].Panes[0].Launch || tmpl.Windows[0].Panes[1].Size != "40%" {
+		t.Errorf("TmuxTemplate(agent) panes = %+v", tmpl.Windows[0].Panes)
+	}
+	if cfg.TmuxTemplate("") != nil || cfg.TmuxTemplate("missing") != nil {
+		t.Error("TmuxTemplate() should return nil for unknown names")
+	}
+
+	cfg.Projects["docs"] = ProjectConfig{TmuxTemplate: "missing"}
+	if err := cfg.validateTmuxTemplates(); err == nil {
+		t.Error("validateTmuxTemplates() should reject unknown project templates")
+	}
+}
+
+func TestConfig_NotifiesOn(t *testing.T) {
+	data := []byte(`
+notify: [waiting]
+projects:
+  api:
+    notify: [waiting, finished]
+  docs:
+    notify: []
+`)
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	if err := cfg.validateNotify(); err != nil {
+		t.Fatalf("validateNotify() error: %v", err)
+	}
+
+	tests := []struct {
+		project string
+		event   string
+		want    bool
+	}{
+		{"web", "waiting", true},
+		{"web", "finished", false}, // Global setting
+		{"api", "finished", true},  // Project override
+		{"docs", "waiting", false}, // Empty list disables notifications
+	}
+	for _, tt := range tests {
+		if got := cfg.NotifiesOn(tt.project, tt.event); got != tt.want {
+			t.Errorf("NotifiesOn(%s, %s) = %v, want %v", tt.project, tt.event, got, tt.want)
+		}
+	}
+
+	if !DefaultConfig().NotifiesOn("web", "finished") {
+		t.Error("all transitions should notify by default")
+	}
+	cfg.Projects["api"] = ProjectConfig{Notify: []string{"idle"}}
+	if err := cfg.validateNotify(); err == nil {
+		t.Error("unknown project notify event should be rejected")
+	}
+}
+
+func TestConfig_TranscriptDirs(t *testing.T) {
+	cfg := DefaultConfig()
+	dir := cfg.TranscriptDirFor("/work/a/app")
+	if filepath.Dir(dir) != util.ExpandPath(constants.DefaultTranscriptDir) || !strings.HasPrefix(filepath.Base(dir), "app-") {
+		t.Errorf("TranscriptDirFor() = %q, want app-<hash> under the default transcript dir", dir)
+	}
+	// Worktrees share the instance name, their transcripts must not
+	if other := cfg.TranscriptDirFor("/work/b/app"); other == dir {
+		t.Errorf("TranscriptDirFor() = %q for two different projects", other)
+	}
+	if got := cfg.ArchiveDirFor("/work/app"); got != "" {
+		t.Errorf("ArchiveDirFor() = %q, want empty while archiving is off", got)
+	}
+
+	cfg.TranscriptDir = "/var/transcripts"
+	cfg.ArchiveTranscripts = true
+	if got := cfg.ArchiveDirFor("/work/app"); filepath.Dir(got) != "/var/transcripts" || got != cfg.TranscriptDirFor("/work/app") {
+		t.Errorf("ArchiveDirFor() = %q, want the project's dir under /var/transcripts", got)
+	}
+}
+
+func TestConfig_TmuxStyle(t *testing.T) {
+	dir := t.TempDir()
+	darkConf := filepath.Join(dir, "tmux.conf")
+	lightConf := filepath.Join(dir, "tmux-light.conf")
+	if err := os.WriteFile(darkConf, []byte("set -g mouse on\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lightConf, []byte("set -g mouse off\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	style, err := cfg.TmuxStyle(true)
+	if err != nil || style.Options["status-style"] != "bg=#D97706,fg=#FFFFFF" || style.Conf != "" {
+		t.Errorf("default style = %+v, %v, want the dark anthropic theme", style, err)
+	}
+	if style, _ := cfg.TmuxStyle(false); style.Options["status-style"] == "bg=#D97706,fg=#FFFFFF" {
+		t.Error("light mode should use the light variant")
+	}
+
+	cfg.TmuxTheme = "none"
+	cfg.TmuxConf = darkConf
+	cfg.TmuxConfLight = lightConf
+	if style, err := cfg.TmuxStyle(true); err != nil || style.Options != nil || style.Conf != "set -g mouse on\n" {
+		t.Errorf("dark style = %+v, %v, want no options and the dark snippet", style, err)
+	}
+	if style, _ := cfg.TmuxStyle(false); style.Conf != "set -g mouse off\n" {
+		t.Errorf("light snippet = %q", style.Conf)
+	}
+
+	cfg.TmuxConf = filepath.Join(dir, "missing.conf")
+	if _, err := cfg.TmuxStyle(true); err == nil {
+		t.Error("TmuxStyle() should fail for an unreadable tmux_conf")
+	}
+}
+
+func TestConfig_ValidateTmuxTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		theme   string
+		themes  map[string]tmux.Theme
+		wantErr bool
+	}{
+		{"default", "", nil, false},
+		{"none", "none", nil, false},
+		{"custom", "mine", map[string]tmux.Theme{"mine": {Dark: map[string]string{"status-style": "bg=blue"}}}, false},
+		{"unknown", "missing", nil, true},
+		{"reserved name", "", map[string]tmux.Theme{"none": {}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.TmuxTheme = tt.theme
+			cfg.TmuxThemes = tt.themes
+			if err := cfg.validateTmuxTheme(); (err != nil) != tt.wantErr {
+				t.Errorf("validateTmuxTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,11 @@
 // This eliminates magic numbers scattered across the codebase.
 package constants
 
+import (
+	"path"
+	"time"
+)
+
 // Container timeout constants (in seconds)
 const (
 	DefaultContainerTimeout = 300  // Default timeout for container operations
@@ -34,18 +39,69 @@ const (
 )
 
 // Reserved branch names that cannot be used for worktrees
+// (default when a repository does not configure reserved_branches)
 var ReservedBranchNames = []string{"main", "master"}
 
-// IsReservedBranchName checks if a branch name is reserved
+// IsReservedBranchName checks if a branch name is reserved by default
 func IsReservedBranchName(name string) bool {
-	for _, reserved := range ReservedBranchNames {
-		if name == reserved {
+	return MatchesReservedBranch(name, ReservedBranchNames)
+}
+
+// MatchesReservedBranch checks if a branch name matches any of the reserved
+// patterns. Patterns are exact names or globs such as "release/*".
+func MatchesReservedBranch(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if name == pattern {
+			return true
+		}
+		if matched, err := path.Match(pattern, name); err == nil && matched {
 			return true
 		}
 	}
 	return false
 }
 
+// Messages recorded by worktree operations
+const (
+	WorktreeStashMessage  = "claude-quick: saved before worktree removal"
+	WorktreeCommitMessage = "WIP: save work before worktree removal"
+	WorktreeLockReason    = "locked from claude-quick"
+)
+
+// Pane preview constants (tmux session picker)
+const (
+	PanePreviewLines    = 15              // Lines of the active pane shown in the preview
+	PanePreviewInterval = 2 * time.Second // How often the preview is refreshed
+	PanePreviewMinWidth = 30              // Narrowest preview panel worth showing
+	PanePreviewMaxWidth = 100             // Widest preview panel
+)
+
+// SendKeysPlaceholder is shown in the empty send keys input
+const SendKeysPlaceholder = "e.g. /compact (empty just presses Enter)"
+
+// DefaultTranscriptDir is where session transcripts are exported to, one subdirectory per instance
+const DefaultTranscriptDir = "~/.local/share/claude-quick/transcripts"
+
+// SessionStateDir is where tmux session layouts are saved while their container is stopped
+const SessionStateDir = "~/.local/share/claude-quick/sessions"
+
+// ContinueFlag makes the agent resume its most recent conversation when sessions are restored
+const ContinueFlag = "--continue"
+
+// TmuxNamePlaceholder is shown in the empty session/window name input
+const TmuxNamePlaceholder = "name"
+
+// Agent monitoring constants
+const (
+	AgentPollInterval = 10 * time.Second // How often all sessions are inspected in the background
+	NotifyTitle       = "claude-quick"   // Title of agent notifications
+)
+
+// Tmux user options set on sessions created by claude-quick
+const (
+	TmuxTemplateOption = "@claude-quick-template" // Name of the session template used
+)
+
 // Default values for configuration
 const (
 	DefaultSessionName       = "main"
@@ -76,4 +132,5 @@ const (
 	DefaultInProgressLabel  = "in-progress"                       // Default label for issues being worked on
 	DefaultLabelColor       = "fbca04"                            // Yellow color for in-progress label
 	DefaultLabelDescription = "Issue is being actively worked on" // Description for auto-created label
+	DefaultMergedPRLimit    = 200                                 // Maximum number of merged PRs checked during cleanup
 )
@@ -124,3 +124,27 @@ func TestStringConstants(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesReservedBranch(t *testing.T) {
+	patterns := []string{"develop", "release/*", "hotfix-*"}
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"exact name", "develop", true},
+		{"glob match", "release/1.2", true},
+		{"prefix glob", "hotfix-login", true},
+		{"glob does not cross slash", "release/1.2/rc1", false},
+		{"unlisted name", "main", false},
+		{"feature branch", "feature/auth", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesReservedBranch(tt.input, patterns); got != tt.expected {
+				t.Errorf("MatchesReservedBranch(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package devcontainer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// BootstrapWorktree copies untracked files from the main repo into a new worktree
+// and runs the post-create commands. Failures are returned as warnings since the
+// worktree itself was created successfully.
+func BootstrapWorktree(worktreePath, branchName string, cfg WorktreeConfig) []string {
+	if len(cfg.Copy) == 0 && len(cfg.PostCreate) == 0 {
+		return nil
+	}
+
+	wtInfo := IsGitWorktree(worktreePath)
+	if wtInfo == nil {
+		return []string{"Worktree bootstrap skipped: not a git worktree"}
+	}
+	mainRepo := wtInfo.MainRepo
+
+	var warnings []string
+	if wtInfo.Bare && len(cfg.Copy) > 0 {
+		// A bare repository has no main checkout holding untracked files
+		warnings = append(warnings, "worktree_copy skipped: bare repository has no main checkout")
+	} else {
+		warnings = copyBootstrapFiles(mainRepo, worktreePath, cfg)
+	}
+	for _, command := range cfg.PostCreate {
+		if err := runPostCreateCommand(mainRepo, worktreePath, branchName, command); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
+
+// copyBootstrapFiles copies or symlinks files matching the configured patterns
+func copyBootstrapFiles(mainRepo, worktreePath string, cfg WorktreeConfig) []string {
+	var warnings []string
+	for _, pattern := range cfg.Copy {
+		matches, err := filepath.Glob(filepath.Join(mainRepo, pattern))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Invalid worktree_copy pattern %q: %v", pattern, err))
+			continue
+		}
+		for _, src := range matches {
+			rel, err := filepath.Rel(mainRepo, src)
+			if err != nil || rel == ".git" || strings.HasPrefix(rel, ".git"+string(filepath.Separator)) {
+				continue // Never copy git metadata
+			}
+			dst := filepath.Join(worktreePath, rel)
+			if _, err := os.Lstat(dst); err == nil {
+				continue // Tracked file or already present - keep the worktree's version
+			}
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				warnings = append(warnings, fmt.Sprintf("Failed to copy %s: %v", rel, err))
+				continue
+			}
+
+			if cfg.CopyMode == CopyModeSymlink {
+				err = os.Symlink(src, dst)
+			} else {
+				err = copyPath(src, dst)
+			}
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("Failed to copy %s: %v", rel, err))
+			}
+		}
+	}
+	return warnings
+}
+
+// copyPath recursively copies a file, symlink or directory preserving permissions
+func copyPath(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+
+	case info.IsDir():
+		if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := copyPath(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return copyFile(src, dst, info.Mode().Perm())
+	}
+}
+
+// copyFile copies a regular file's contents to dst with the given permissions
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// runPostCreateCommand runs a shell command on the host inside the new worktree.
+// The main repo, worktree path and branch are exposed as environment variables.
+func runPostCreateCommand(mainRepo, worktreePath, branchName, command string) error {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = worktreePath
+	cmd.Env = append(os.Environ(),
+		"CLAUDE_QUICK_MAIN_REPO="+mainRepo,
+		"CLAUDE_QUICK_WORKTREE="+worktreePath,
+		"CLAUDE_QUICK_BRANCH="+branchName,
+	)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("post-create command %q failed: %s", command, lastLine(output.String(), err))
+	}
+	return nil
+}
+
+// lastLine returns the last non-empty line of command output, or the error if there is none
+func lastLine(output string, err error) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+	return err.Error()
+}
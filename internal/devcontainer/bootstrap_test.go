@@ -0,0 +1,105 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestFile writes content to path, creating parent directories
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestBootstrapWorktree_Copy(t *testing.T) {
+	repo := initTestRepo(t)
+	writeTestFile(t, filepath.Join(repo, ".env"), "SECRET=1\n")
+	writeTestFile(t, filepath.Join(repo, "certs", "dev.pem"), "cert\n")
+	writeTestFile(t, filepath.Join(repo, "README.md"), "modified in main\n")
+	wt := addTestWorktree(t, repo, "feature")
+
+	warnings := BootstrapWorktree(wt.Path, wt.Branch, WorktreeConfig{
+		Copy: []string{".env", "certs", "README.md", "missing-*", ".git"},
+	})
+	if len(warnings) != 0 {
+		t.Fatalf("BootstrapWorktree() warnings = %v, want none", warnings)
+	}
+
+	data, err := os.ReadFile(filepath.Join(wt.Path, ".env"))
+	if err != nil || string(data) != "SECRET=1\n" {
+		t.Errorf(".env = %q, %v; want copied contents", data, err)
+	}
+	info, err := os.Lstat(filepath.Join(wt.Path, ".env"))
+	if err != nil || info.Mode()&os.ModeSymlink != 0 || info.Mode().Perm() != 0600 {
+		t.Errorf(".env should be a regular file with mode 0600, got %v (%v)", info.Mode(), err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "certs", "dev.pem")); err != nil {
+		t.Errorf("directory should be copied recursively: %v", err)
+	}
+
+	// Tracked files already in the worktree are left alone
+	data, _ = os.ReadFile(filepath.Join(wt.Path, "README.md"))
+	if string(data) != "hello\n" {
+		t.Errorf("README.md = %q, existing file should not be overwritten", data)
+	}
+}
+
+func TestBootstrapWorktree_Symlink(t *testing.T) {
+	repo := initTestRepo(t)
+	writeTestFile(t, filepath.Join(repo, ".env.local"), "A=1\n")
+	wt := addTestWorktree(t, repo, "feature")
+
+	warnings := BootstrapWorktree(wt.Path, wt.Branch, WorktreeConfig{
+		Copy:     []string{".env*"},
+		CopyMode: CopyModeSymlink,
+	})
+	if len(warnings) != 0 {
+		t.Fatalf("BootstrapWorktree() warnings = %v, want none", warnings)
+	}
+
+	target, err := os.Readlink(filepath.Join(wt.Path, ".env.local"))
+	if err != nil {
+		t.Fatalf(".env.local should be a symlink: %v", err)
+	}
+	if target != filepath.Join(repo, ".env.local") {
+		t.Errorf("symlink target = %q, want %q", target, filepath.Join(repo, ".env.local"))
+	}
+}
+
+func TestBootstrapWorktree_PostCreate(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+
+	warnings := BootstrapWorktree(wt.Path, wt.Branch, WorktreeConfig{
+		PostCreate: []string{
+			`printf '%s|%s|%s' "$CLAUDE_QUICK_MAIN_REPO" "$CLAUDE_QUICK_WORKTREE" "$CLAUDE_QUICK_BRANCH" > env.out`,
+			"echo boom >&2; exit 3",
+		},
+	})
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "boom") {
+		t.Errorf("warnings = %v, want one warning mentioning the failed command output", warnings)
+	}
+
+	data, err := os.ReadFile(filepath.Join(wt.Path, "env.out"))
+	if err != nil {
+		t.Fatalf("post-create command should run in the worktree: %v", err)
+	}
+	want := repo + "|" + wt.Path + "|feature"
+	if string(data) != want {
+		t.Errorf("post-create env = %q, want %q", data, want)
+	}
+}
+
+func TestBootstrapWorktree_Empty(t *testing.T) {
+	if warnings := BootstrapWorktree(t.TempDir(), "feature", WorktreeConfig{}); warnings != nil {
+		t.Errorf("BootstrapWorktree() with empty config = %v, want nil", warnings)
+	}
+}
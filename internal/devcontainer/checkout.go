@@ -0,0 +1,32 @@
+package devcontainer
+
+import "path/filepath"
+
+// applySparseCheckout restricts a worktree created with --no-checkout to the given
+// cone directories and populates it. Sparse-checkout settings are per worktree, so
+// the main worktree and other worktrees keep their full checkout.
+func applySparseCheckout(worktreePath string, dirs []string) error {
+	args := append([]string{"sparse-checkout", "set", "--cone"}, dirs...)
+	if err := gitRun(worktreePath, "failed to configure sparse-checkout", args...); err != nil {
+		return err
+	}
+	return populateWorktree(worktreePath)
+}
+
+// populateWorktree checks out HEAD into a worktree created with --no-checkout
+func populateWorktree(worktreePath string) error {
+	return gitRun(worktreePath, "failed to check out worktree", "checkout")
+}
+
+// updateSubmodules initializes and checks out the submodules of a new worktree
+// according to mode. Does nothing if the repository has no submodules.
+func updateSubmodules(worktreePath, mode string) error {
+	if mode == SubmodulesOff || !fileExists(filepath.Join(worktreePath, ".gitmodules")) {
+		return nil
+	}
+	args := []string{"submodule", "update", "--init"}
+	if mode == SubmodulesRecursive {
+		args = append(args, "--recursive")
+	}
+	return gitRun(worktreePath, "submodule update failed", args...)
+}
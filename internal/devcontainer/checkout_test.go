@@ -0,0 +1,105 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateWorktree_SparseCheckout(t *testing.T) {
+	repo := initTestRepo(t)
+	commitTestFile(t, repo, "packages/api/main.go", "package main\n")
+	commitTestFile(t, repo, "packages/web/index.js", "\n")
+
+	wtPath, warning, err := CreateWorktree(repo, "sparse", false, WorktreeConfig{SparseCheckout: []string{"packages/api"}})
+	if err != nil {
+		t.Fatalf("CreateWorktree() error: %v", err)
+	}
+	if warning != "" {
+		t.Errorf("CreateWorktree() warning = %q, want none", warning)
+	}
+
+	// Cone mode keeps top-level files and the selected directories
+	for _, name := range []string{"README.md", "packages/api/main.go"} {
+		if !fileExists(filepath.Join(wtPath, name)) {
+			t.Errorf("%s missing from sparse worktree", name)
+		}
+	}
+	if fileExists(filepath.Join(wtPath, "packages", "web")) {
+		t.Error("packages/web should not be checked out")
+	}
+	if status := runTestGit(t, wtPath, "status", "--porcelain"); status != "" {
+		t.Errorf("sparse worktree should be clean, got status %q", status)
+	}
+
+	// The main worktree keeps its full checkout
+	if !fileExists(filepath.Join(repo, "packages", "web", "index.js")) {
+		t.Error("main worktree lost files after creating a sparse worktree")
+	}
+}
+
+// addTestSubmodule adds the repository at url as a submodule of repo at path and commits it
+func addTestSubmodule(t *testing.T, repo, url, path string) {
+	t.Helper()
+	// Local file:// submodules are disabled by default since git 2.38.1
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+	runTestGit(t, repo, "submodule", "add", "-q", url, path)
+	runTestGit(t, repo, "commit", "-q", "-m", "add submodule "+path)
+}
+
+func TestCreateWorktree_Submodules(t *testing.T) {
+	sub := initTestRepo(t)
+	repo := initTestRepo(t)
+	addTestSubmodule(t, repo, sub, "libs/sub")
+
+	tests := []struct {
+		name      string
+		branch    string
+		mode      string
+		wantFiles bool
+	}{
+		{"default initializes", "sub-default", "", true},
+		{"recursive initializes", "sub-recursive", SubmodulesRecursive, true},
+		{"off leaves empty", "sub-off", SubmodulesOff, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wtPath, warning, err := CreateWorktree(repo, tt.branch, false, WorktreeConfig{Submodules: tt.mode})
+			if err != nil {
+				t.Fatalf("CreateWorktree() error: %v", err)
+			}
+			if warning != "" {
+				t.Errorf("CreateWorktree() warning = %q, want none", warning)
+			}
+			got := fileExists(filepath.Join(wtPath, "libs", "sub", "README.md"))
+			if got != tt.wantFiles {
+				t.Errorf("submodule checked out = %v, want %v", got, tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestCreateWorktree_SubmoduleFailureIsWarning(t *testing.T) {
+	repo := initTestRepo(t)
+	sub := initTestRepo(t)
+	addTestSubmodule(t, repo, sub, "libs/sub")
+	if err := os.RemoveAll(sub); err != nil {
+		t.Fatalf("failed to remove submodule origin: %v", err)
+	}
+	// Drop the cloned module so the worktree has to fetch from the missing origin
+	if err := os.RemoveAll(filepath.Join(repo, ".git", "modules")); err != nil {
+		t.Fatalf("failed to remove submodule clone: %v", err)
+	}
+
+	wtPath, warning, err := CreateWorktree(repo, "broken-sub", false, WorktreeConfig{})
+	if err != nil {
+		t.Fatalf("CreateWorktree() error: %v", err)
+	}
+	if wtPath == "" || !strings.Contains(warning, "submodule update failed") {
+		t.Errorf("CreateWorktree() = %q, warning %q, want worktree with submodule warning", wtPath, warning)
+	}
+}
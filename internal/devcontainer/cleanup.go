@@ -0,0 +1,136 @@
+package devcontainer
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsBranchMerged returns true if branch is fully merged into base in the given repository.
+// A branch that never got a commit of its own is an ancestor of base too, but has
+// nothing merged (it is usually one an agent just started on), so it doesn't count.
+func IsBranchMerged(mainRepo, branch, base string) bool {
+	if branch == "" || base == "" || branch == base {
+		return false
+	}
+	_, err := gitOutput(mainRepo, "merge-base", "--is-ancestor", "refs/heads/"+branch, "refs/heads/"+base)
+	return err == nil && branchHasOwnCommits(mainRepo, branch, base)
+}
+
+// branchHasOwnCommits reports whether branch gained commits after it was created
+func branchHasOwnCommits(mainRepo, branch, base string) bool {
+	tip, err := gitOutput(mainRepo, "rev-parse", "refs/heads/"+branch)
+	if err != nil {
+		return false
+	}
+	// The oldest reflog entry is the commit the branch was created at
+	if reflog, err := gitOutput(mainRepo, "log", "-g", "--format=%H", "refs/heads/"+branch); err == nil && reflog != "" {
+		entries := strings.Split(reflog, "\n")
+		return entries[len(entries)-1] != tip
+	}
+	// Without a reflog (e.g. bare repositories), a tip on base's own first-parent
+	// history was never a separate commit. Fast-forward merges look the same, so
+	// they are conservatively not reported.
+	history, err := gitOutput(mainRepo, "rev-list", "--first-parent", "refs/heads/"+base)
+	if err != nil {
+		return false
+	}
+	return !slices.Contains(strings.Split(history, "\n"), tip)
+}
+
+// BranchLastActivity returns the commit time of the latest commit in a worktree.
+// Returns the zero time if it cannot be determined.
+func BranchLastActivity(worktreePath string) time.Time {
+	output, err := gitOutput(worktreePath, "log", "-1", "--format=%ct")
+	if err != nil {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(output, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// NewCleanupCandidate creates a CleanupCandidate for a worktree instance,
+// flagging any work that removing it would lose
+func NewCleanupCandidate(inst ContainerInstance, reason string) CleanupCandidate {
+	c := CleanupCandidate{
+		Instance:     inst,
+		Reason:       reason,
+		LastActivity: BranchLastActivity(inst.Path),
+	}
+	if inst.Worktree != nil {
+		c.AtRisk = cleanupRisk(*inst.Worktree)
+	}
+	return c
+}
+
+// cleanupRisk describes the work removing a worktree and its branch would lose
+// (uncommitted changes, commits found nowhere else), or "" if there is none
+func cleanupRisk(wt WorktreeInfo) string {
+	status, err := GetWorktreeStatus(wt)
+	if err != nil {
+		return "status unknown: " + err.Error()
+	}
+	var risks []string
+	if n := len(status.ChangedFiles); n > 0 {
+		risks = append(risks, fmt.Sprintf("%d uncommitted file(s)", n))
+	}
+	// Commits merged into the base survive the branch
+	if status.UnpushedCommits > 0 && !status.Merged {
+		risks = append(risks, fmt.Sprintf("%d unpushed commit(s)", status.UnpushedCommits))
+	}
+	return strings.Join(risks, ", ")
+}
+
+// FindMergedWorktrees returns the non-main worktrees whose branches are merged
+// into their repository's default branch
+func FindMergedWorktrees(instances []ContainerInstance) []CleanupCandidate {
+	var candidates []CleanupCandidate
+	baseBranches := make(map[string]string) // Cache default branch per main repo
+
+	for _, inst := range instances {
+		// Locked worktrees are protected from removal
+		if inst.Worktree == nil || inst.Worktree.IsMain || inst.Worktree.Locked {
+			continue
+		}
+		mainRepo := inst.Worktree.MainRepo
+		base, ok := baseBranches[mainRepo]
+		if !ok {
+			base = DefaultBranch(mainRepo)
+			baseBranches[mainRepo] = base
+		}
+		if IsBranchMerged(mainRepo, inst.Worktree.Branch, base) {
+			candidates = append(candidates, NewCleanupCandidate(inst, "merged into "+base))
+		}
+	}
+	return candidates
+}
+
+// CleanupWorktree removes a finished worktree along with its branch,
+// stopped container and credential file
+func CleanupWorktree(c CleanupCandidate) error {
+	inst := c.Instance
+	if inst.Worktree == nil || inst.Worktree.IsMain {
+		return fmt.Errorf("%s: not a removable worktree", inst.DisplayName())
+	}
+
+	// Stops the container, removes its credential file and the worktree directory
+	if err := RemoveWorktree(inst.Path, inst.Worktree.MainRepo); err != nil {
+		return fmt.Errorf("%s: %w", inst.DisplayName(), err)
+	}
+
+	if inst.Worktree.Branch != "" {
+		if _, err := DeleteBranch(inst.Worktree.MainRepo, inst.Worktree.Branch, false); err != nil {
+			return fmt.Errorf("%s: %w", inst.DisplayName(), err)
+		}
+	}
+
+	if err := RemoveContainer(inst.Path); err != nil {
+		return fmt.Errorf("%s: %w", inst.DisplayName(), err)
+	}
+	return nil
+}
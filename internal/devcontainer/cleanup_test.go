@@ -0,0 +1,101 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsBranchMerged(t *testing.T) {
+	repo := initTestRepo(t)
+	merged := addTestWorktree(t, repo, "merged")
+	unmerged := addTestWorktree(t, repo, "unmerged")
+	fresh := addTestWorktree(t, repo, "fresh")
+
+	if err := os.WriteFile(filepath.Join(unmerged.Path, "new.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runTestGit(t, unmerged.Path, "add", "new.txt")
+	runTestGit(t, unmerged.Path, "commit", "-q", "-m", "unmerged work")
+	runTestGit(t, merged.Path, "commit", "-q", "--allow-empty", "-m", "merged work")
+	runTestGit(t, repo, "merge", "-q", "--ff-only", merged.Branch)
+
+	tests := []struct {
+		name     string
+		branch   string
+		base     string
+		expected bool
+	}{
+		{"branch merged into base", merged.Branch, "main", true},
+		{"branch without own commits", fresh.Branch, "main", false},
+		{"branch ahead of base", unmerged.Branch, "main", false},
+		{"branch equals base", "main", "main", false},
+		{"empty base", merged.Branch, "", false},
+		{"missing branch", "does-not-exist", "main", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBranchMerged(repo, tt.branch, tt.base); got != tt.expected {
+				t.Errorf("IsBranchMerged(%q, %q) = %v, want %v", tt.branch, tt.base, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBranchLastActivity(t *testing.T) {
+	repo := initTestRepo(t)
+
+	got := BranchLastActivity(repo)
+	if got.IsZero() {
+		t.Fatal("BranchLastActivity() returned zero time for repo with commits")
+	}
+	if since := time.Since(got); since < 0 || since > time.Hour {
+		t.Errorf("BranchLastActivity() = %v, want a recent time", got)
+	}
+
+	if got := BranchLastActivity(filepath.Join(t.TempDir(), "missing")); !got.IsZero() {
+		t.Errorf("BranchLastActivity() on missing dir = %v, want zero time", got)
+	}
+}
+
+func TestFindMergedWorktrees(t *testing.T) {
+	repo := initTestRepo(t)
+	merged := addTestWorktree(t, repo, "merged")
+	unmerged := addTestWorktree(t, repo, "unmerged")
+	fresh := addTestWorktree(t, repo, "fresh")
+	runTestGit(t, unmerged.Path, "commit", "-q", "--allow-empty", "-m", "unmerged work")
+	runTestGit(t, merged.Path, "commit", "-q", "--allow-empty", "-m", "merged work")
+	runTestGit(t, repo, "merge", "-q", "--ff-only", merged.Branch)
+	if err := os.WriteFile(filepath.Join(merged.Path, "scratch.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	main := newMainWorktreeInfo(repo, "main")
+	instances := []ContainerInstance{
+		{Project: Project{Name: "repo", Path: repo}, Worktree: &main},
+		{Project: Project{Name: "repo", Path: merged.Path}, Worktree: &merged},
+		{Project: Project{Name: "repo", Path: unmerged.Path}, Worktree: &unmerged},
+		{Project: Project{Name: "repo", Path: fresh.Path}, Worktree: &fresh},
+		{Project: Project{Name: "plain", Path: t.TempDir()}},
+	}
+
+	candidates := FindMergedWorktrees(instances)
+	if len(candidates) != 1 {
+		t.Fatalf("FindMergedWorktrees() returned %d candidates, want 1", len(candidates))
+	}
+	if candidates[0].Instance.Path != merged.Path {
+		t.Errorf("candidate path = %q, want %q", candidates[0].Instance.Path, merged.Path)
+	}
+	if candidates[0].Reason != "merged into main" {
+		t.Errorf("candidate reason = %q, want %q", candidates[0].Reason, "merged into main")
+	}
+	if candidates[0].LastActivity.IsZero() {
+		t.Error("candidate LastActivity should be set")
+	}
+	// Merged commits are safe, the untracked file is not
+	if candidates[0].AtRisk != "1 uncommitted file(s)" {
+		t.Errorf("candidate AtRisk = %q, want %q", candidates[0].AtRisk, "1 uncommitted file(s)")
+	}
+}
@@ -0,0 +1,126 @@
+package devcontainer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+)
+
+// localFolderLabel is the label the devcontainer CLI records a container's project path in
+const localFolderLabel = "devcontainer.local_folder"
+
+// localFolderFormat prints a container's project path with docker ps or docker events
+const localFolderFormat = `{{index .Labels "` + localFolderLabel + `"}}`
+
+// RunningContainerPaths returns the project paths of all running devcontainers
+func RunningContainerPaths() ([]string, error) {
+	cmd := exec.Command("docker", "ps", "--filter", "label="+localFolderLabel, "--format", localFolderFormat)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running containers: %w", err)
+	}
+	return parseLines(string(output)), nil
+}
+
+// SweepCredentials removes the credential files of projects whose containers
+// aren't running, and returns how many were removed. Nothing is removed if
+// docker can't be asked which containers are running.
+func SweepCredentials() (int, error) {
+	running, err := RunningContainerPaths()
+	if err != nil {
+		return 0, err
+	}
+	return auth.SweepCredentialFiles(running)
+}
+
+// ContainerExit is a devcontainer stopping, as reported by docker events
+type ContainerExit struct {
+	Path string    // Project path of the stopped container
+	Time time.Time // When it stopped (zero if unknown)
+}
+
+// CleanupExitedCredentials removes a project's credential file after its
+// container stopped, unless it is running again by now or the file was written
+// after the stop (e.g. the container was restarted). Like SweepCredentials,
+// nothing is removed if docker can't be asked which containers are running.
+func CleanupExitedCredentials(exit ContainerExit) error {
+	running, err := RunningContainerPaths()
+	if err != nil {
+		return err
+	}
+	path := filepath.Clean(exit.Path)
+	if slices.ContainsFunc(running, func(p string) bool { return filepath.Clean(p) == path }) {
+		return nil
+	}
+	if info, err := os.Stat(auth.CredentialFilePath(path)); err == nil && !exit.Time.IsZero() && info.ModTime().After(exit.Time) {
+		return nil
+	}
+	return auth.CleanupCredentialFile(path)
+}
+
+// WatchContainerExits follows docker's die and stop events for devcontainers and
+// sends each stopped container, including ones stopped outside claude-quick.
+// docker events runs until ctx is cancelled; the channel is closed when it exits.
+func WatchContainerExits(ctx context.Context) (<-chan ContainerExit, error) {
+	cmd := exec.CommandContext(ctx, "docker", "events",
+		"--filter", "type=container",
+		"--filter", "event=die",
+		"--filter", "event=stop",
+		"--filter", "label="+localFolderLabel,
+		"--format", `{{.TimeNano}} {{index .Actor.Attributes "`+localFolderLabel+`"}}`)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch containers: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to watch containers: %w", err)
+	}
+
+	exits := make(chan ContainerExit)
+	go func() {
+		defer close(exits)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			exit, ok := parseContainerExit(scanner.Text())
+			if !ok {
+				continue
+			}
+			select {
+			case exits <- exit:
+			case <-ctx.Done():
+			}
+		}
+		_ = cmd.Wait()
+	}()
+	return exits, nil
+}
+
+// parseContainerExit parses a "<unix nanoseconds> <project path>" docker events line
+func parseContainerExit(line string) (ContainerExit, bool) {
+	stamp, path, _ := strings.Cut(strings.TrimSpace(line), " ")
+	nanos, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil || path == "" {
+		return ContainerExit{}, false
+	}
+	return ContainerExit{Path: path, Time: time.Unix(0, nanos)}, true
+}
+
+// parseLines returns the non-empty trimmed lines of output
+func parseLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,238 @@
+package devcontainer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+)
+
+// fakeDockerScript stands in for docker: containers for the paths listed in
+// $FAKE_DOCKER_RUNNING are running, and docker events prints a stop of each path
+// in $FAKE_DOCKER_EVENTS, then keeps following if $FAKE_DOCKER_FOLLOW is set
+const fakeDockerScript = `#!/bin/sh
+case "$1" in
+ps)
+	want=""
+	for arg in "$@"; do
+		case "$arg" in
+		label=devcontainer.local_folder=*) want="${arg#label=devcontainer.local_folder=}" ;;
+		status=exited) exit 0 ;;
+		esac
+	done
+	for path in $FAKE_DOCKER_RUNNING; do
+		if [ -z "$want" ]; then
+			echo "$path"
+		elif [ "$path" = "$want" ]; then
+			echo c0ffee
+		fi
+	done
+	;;
+events)
+	for path in $FAKE_DOCKER_EVENTS; do
+		echo "1700000000000000000 $path"
+	done
+	if [ -n "$FAKE_DOCKER_FOLLOW" ]; then
+		exec sleep 60
+	fi
+	;;
+esac
+`
+
+// fakeDocker puts fakeDockerScript first on PATH and keeps credentials in a temp dir
+func fakeDocker(t *testing.T, running ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(fakeDockerScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_DOCKER_RUNNING", strings.Join(running, " "))
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+}
+
+// writeTestCredentials writes a credential file for each project path
+func writeTestCredentials(t *testing.T, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		if err := auth.WriteCredentialFile(path, map[string]string{"TOKEN": "secret"}); err != nil {
+			t.Fatalf("WriteCredentialFile(%s) error = %v", path, err)
+		}
+	}
+}
+
+// hasCredentials reports whether a project's credential file exists
+func hasCredentials(path string) bool {
+	_, err := os.Stat(auth.CredentialFilePath(path))
+	return err == nil
+}
+
+func TestRunningContainerPaths(t *testing.T) {
+	fakeDocker(t, "/work/a", "/work/b")
+
+	paths, err := RunningContainerPaths()
+	if err != nil {
+		t.Fatalf("RunningContainerPaths() error = %v", err)
+	}
+	if want := []string{"/work/a", "/work/b"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("RunningContainerPaths() = %v, want %v", paths, want)
+	}
+}
+
+func TestSweepCredentials_Startup(t *testing.T) {
+	fakeDocker(t, "/work/running")
+	writeTestCredentials(t, "/work/running", "/work/crashed")
+
+	removed, err := SweepCredentials()
+	if err != nil || removed != 1 {
+		t.Fatalf("SweepCredentials() = %d, %v; want 1, nil", removed, err)
+	}
+	if !hasCredentials("/work/running") {
+		t.Error("SweepCredentials() removed the running container's credentials")
+	}
+	if hasCredentials("/work/crashed") {
+		t.Error("SweepCredentials() kept credentials of a container that isn't running")
+	}
+}
+
+func TestSweepCredentials_DockerUnavailable(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	t.Setenv("PATH", t.TempDir()) // No docker
+	writeTestCredentials(t, "/work/a")
+
+	if _, err := SweepCredentials(); err == nil {
+		t.Error("SweepCredentials() without docker error = nil, want error")
+	}
+	if !hasCredentials("/work/a") {
+		t.Error("SweepCredentials() removed credentials without knowing what is running")
+	}
+}
+
+func TestWatchContainerExits(t *testing.T) {
+	fakeDocker(t)
+	t.Setenv("FAKE_DOCKER_EVENTS", "/work/a /work/b")
+
+	exits, err := WatchContainerExits(context.Background())
+	if err != nil {
+		t.Fatalf("WatchContainerExits() error = %v", err)
+	}
+	var got []ContainerExit
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case exit, ok := <-exits:
+			if !ok {
+				done = true
+				break
+			}
+			got = append(got, exit)
+		case <-timeout:
+			t.Fatal("timed out waiting for docker events to end")
+		}
+	}
+	stopped := time.Unix(0, 1700000000000000000)
+	want := []ContainerExit{{Path: "/work/a", Time: stopped}, {Path: "/work/b", Time: stopped}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("exits = %v, want %v", got, want)
+	}
+}
+
+func TestWatchContainerExits_Cancel(t *testing.T) {
+	fakeDocker(t)
+	t.Setenv("FAKE_DOCKER_FOLLOW", "1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exits, err := WatchContainerExits(ctx)
+	if err != nil {
+		t.Fatalf("WatchContainerExits() error = %v", err)
+	}
+	cancel()
+	select {
+	case _, ok := <-exits:
+		if ok {
+			t.Error("received an exit after cancelling, want the channel closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("docker events kept running after the context was cancelled")
+	}
+}
+
+func TestParseContainerExit(t *testing.T) {
+	exit, ok := parseContainerExit("1700000000000000000 /work/my app\n")
+	if !ok || exit.Path != "/work/my app" || !exit.Time.Equal(time.Unix(0, 1700000000000000000)) {
+		t.Errorf("parseContainerExit() = %v, %v; want /work/my app at the event time", exit, ok)
+	}
+	for _, line := range []string{"", "1700000000000000000", "/work/app"} {
+		if _, ok := parseContainerExit(line); ok {
+			t.Errorf("parseContainerExit(%q) ok = true, want false", line)
+		}
+	}
+}
+
+func TestCleanupExitedCredentials(t *testing.T) {
+	fakeDocker(t, "/work/restarted")
+	writeTestCredentials(t, "/work/stopped", "/work/restarted")
+	stopped := time.Now()
+
+	for _, path := range []string{"/work/stopped", "/work/restarted"} {
+		if err := CleanupExitedCredentials(ContainerExit{Path: path, Time: stopped}); err != nil {
+			t.Fatalf("CleanupExitedCredentials(%s) error = %v", path, err)
+		}
+	}
+	if hasCredentials("/work/stopped") {
+		t.Error("CleanupExitedCredentials() kept credentials of a stopped container")
+	}
+	if !hasCredentials("/work/restarted") {
+		t.Error("CleanupExitedCredentials() removed credentials of a container that is running again")
+	}
+}
+
+func TestCleanupExitedCredentials_RestartRace(t *testing.T) {
+	fakeDocker(t) // The restarted container isn't reported as running yet
+	// The restart's die event is handled only after restartContainer wrote the
+	// credentials again
+	died := time.Now().Add(-time.Second)
+	writeTestCredentials(t, "/work/app")
+
+	if err := CleanupExitedCredentials(ContainerExit{Path: "/work/app", Time: died}); err != nil {
+		t.Fatalf("CleanupExitedCredentials() error = %v", err)
+	}
+	if !hasCredentials("/work/app") {
+		t.Error("CleanupExitedCredentials() removed credentials written after the container stopped")
+	}
+}
+
+func TestCleanupExitedCredentials_DockerUnavailable(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	t.Setenv("PATH", t.TempDir()) // No docker
+	writeTestCredentials(t, "/work/a")
+
+	if err := CleanupExitedCredentials(ContainerExit{Path: "/work/a"}); err == nil {
+		t.Error("CleanupExitedCredentials() without docker error = nil, want error")
+	}
+	if !hasCredentials("/work/a") {
+		t.Error("CleanupExitedCredentials() removed credentials without knowing what is running")
+	}
+}
+
+func TestRemoveWorktree_CleansUpCredentials(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	fakeDocker(t)
+	writeTestCredentials(t, wt.Path)
+
+	if err := RemoveWorktree(wt.Path); err != nil {
+		t.Fatalf("RemoveWorktree() error = %v", err)
+	}
+	if hasCredentials(wt.Path) {
+		t.Error("RemoveWorktree() kept the worktree's credentials")
+	}
+	if _, err := os.Stat(wt.Path); !os.IsNotExist(err) {
+		t.Error("RemoveWorktree() kept the worktree directory")
+	}
+}
@@ -0,0 +1,105 @@
+package devcontainer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+)
+
+// credentialExcludePattern keeps the legacy credential file out of git in
+// every worktree of a repository
+const credentialExcludePattern = "/" + auth.LegacyCredFileName
+
+// GuardCredentialFile makes sure git never picks up the legacy credential file
+// (which older versions wrote into projects): it is added to the repository's
+// info/exclude, shared by the main worktree and all linked ones. Returns a
+// warning if the file is already tracked or git still doesn't ignore it, or ""
+// if all is well or the project isn't in a git repository.
+func GuardCredentialFile(projectPath string) string {
+	wt := IsGitWorktree(projectPath)
+	if wt == nil {
+		return ""
+	}
+	if err := addGitExclude(wt.CommonDir, credentialExcludePattern); err != nil {
+		return fmt.Sprintf("could not exclude %s from git: %v", auth.LegacyCredFileName, err)
+	}
+
+	if tracked, err := gitOutput(projectPath, "ls-files", "--", auth.LegacyCredFileName); err == nil && tracked != "" {
+		return fmt.Sprintf("%s is committed in %s - rotate the credentials it holds and remove it from git history",
+			auth.LegacyCredFileName, filepath.Base(projectPath))
+	}
+	// check-ignore exits 1 when the file is not ignored (e.g. a .gitignore rule re-includes
+	// it); anything else is git failing, which says nothing about the file
+	cmd := exec.Command("git", "-C", projectPath, "check-ignore", "-q", "--", auth.LegacyCredFileName)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return fmt.Sprintf("%s is not ignored by git in %s - check .gitignore for a rule that re-includes it",
+			auth.LegacyCredFileName, filepath.Base(projectPath))
+	default:
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		return fmt.Sprintf("could not check that git ignores %s in %s: %v",
+			auth.LegacyCredFileName, filepath.Base(projectPath), err)
+	}
+}
+
+// LegacyCredentialFileWarnings guards the projects that still have a legacy
+// credential file in their directory and returns their warnings
+func LegacyCredentialFileWarnings(instances []ContainerInstance) []string {
+	var warnings []string
+	for _, inst := range instances {
+		if _, err := os.Lstat(filepath.Join(inst.Path, auth.LegacyCredFileName)); err != nil {
+			continue
+		}
+		if warning := GuardCredentialFile(inst.Path); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+// addGitExclude appends pattern to <commonDir>/info/exclude unless it is already there
+func addGitExclude(commonDir, pattern string) error {
+	path := filepath.Join(commonDir, "info", "exclude")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	var entry strings.Builder
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		entry.WriteString("\n")
+	}
+	entry.WriteString("# Added by claude-quick: credentials must never be committed\n")
+	entry.WriteString(pattern + "\n")
+	if _, err := f.WriteString(entry.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,101 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+)
+
+func TestAddGitExclude(t *testing.T) {
+	commonDir := t.TempDir()
+	path := filepath.Join(commonDir, "info", "exclude")
+
+	// Creates info/exclude when missing, and doesn't add the pattern twice
+	for i := 0; i < 2; i++ {
+		if err := addGitExclude(commonDir, credentialExcludePattern); err != nil {
+			t.Fatalf("addGitExclude() error = %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), credentialExcludePattern+"\n"); n != 1 {
+		t.Errorf("exclude contains the pattern %d times, want 1:\n%s", n, data)
+	}
+
+	// Existing entries are kept, even without a trailing newline
+	if err := os.WriteFile(path, []byte("*.swp"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := addGitExclude(commonDir, credentialExcludePattern); err != nil {
+		t.Fatalf("addGitExclude() error = %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "*.swp\n#") || !strings.HasSuffix(string(data), "\n"+credentialExcludePattern+"\n") {
+		t.Errorf("exclude = %q, want the pattern appended after *.swp", data)
+	}
+}
+
+func TestGuardCredentialFile(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+
+	if warning := GuardCredentialFile(wt.Path); warning != "" {
+		t.Errorf("GuardCredentialFile() = %q, want no warning", warning)
+	}
+	// The exclude lives in the common dir, so it covers the main worktree too
+	if err := os.WriteFile(filepath.Join(repo, auth.LegacyCredFileName), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if status := runTestGit(t, repo, "status", "--porcelain"); status != "" {
+		t.Errorf("git status = %q, want the credential file ignored", status)
+	}
+
+	// A .gitignore rule re-including the file defeats the exclude
+	if err := os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("!"+auth.LegacyCredFileName+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if warning := GuardCredentialFile(repo); !strings.Contains(warning, "not ignored") {
+		t.Errorf("GuardCredentialFile() = %q, want a not-ignored warning", warning)
+	}
+
+	// A committed file is reported
+	runTestGit(t, repo, "add", "-f", auth.LegacyCredFileName)
+	runTestGit(t, repo, "commit", "-q", "-m", "oops")
+	if warning := GuardCredentialFile(repo); !strings.Contains(warning, "is committed") {
+		t.Errorf("GuardCredentialFile() = %q, want a committed warning", warning)
+	}
+
+	// Projects outside git have nothing to guard
+	if warning := GuardCredentialFile(t.TempDir()); warning != "" {
+		t.Errorf("GuardCredentialFile() outside git = %q, want none", warning)
+	}
+	// git failing (exit 128) is reported as such, not as the file being unignored
+	t.Setenv("GIT_DIR", filepath.Join(t.TempDir(), "missing"))
+	if warning := GuardCredentialFile(repo); !strings.Contains(warning, "could not check") {
+		t.Errorf("GuardCredentialFile() with git failing = %q, want a could-not-check warning", warning)
+	}
+}
+
+func TestLegacyCredentialFileWarnings(t *testing.T) {
+	repo := initTestRepo(t)
+	clean := addTestWorktree(t, repo, "clean")
+	leaked := addTestWorktree(t, repo, "leaked")
+	if err := os.WriteFile(filepath.Join(leaked.Path, auth.LegacyCredFileName), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, leaked.Path, "add", "-f", auth.LegacyCredFileName)
+	runTestGit(t, leaked.Path, "commit", "-q", "-m", "oops")
+
+	warnings := LegacyCredentialFileWarnings([]ContainerInstance{
+		{Project: Project{Name: "clean", Path: clean.Path}},
+		{Project: Project{Name: "leaked", Path: leaked.Path}},
+	})
+	if len(warnings) != 1 || !strings.Contains(warnings[0], filepath.Base(leaked.Path)) {
+		t.Errorf("LegacyCredentialFileWarnings() = %v, want one warning for the leaked worktree", warnings)
+	}
+}
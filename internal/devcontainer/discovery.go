@@ -132,7 +132,7 @@ func DiscoverInstances(searchPaths []string, maxDepth int, excludedDirs []string
 			wtCopy := wt
 			instances = append(instances, ContainerInstance{
 				Project: Project{
-					Name: filepath.Base(mainRepo), // Use main repo name for all
+					Name: wt.RepoName(), // Use main repo name for all
 					Path: wt.Path,
 				},
 				ConfigPath: mainConfigPath,
@@ -15,11 +15,20 @@
 //
 // # Key Files
 //
+//   - bootstrap.go: Copying untracked files and post-create commands for new worktrees
+//   - checkout.go: Sparse-checkout cones and submodule initialization for new worktrees
+//   - cleanup.go: Finding and removing finished worktrees
+//   - credential_cleanup.go: Removing credentials of stopped containers (startup sweep, docker events)
+//   - credential_guard.go: Keeping the legacy in-project credential file out of git
 //   - discovery.go: Recursive devcontainer.json scanner
 //   - docker.go: Container lifecycle (up, stop, restart, status checks)
+//   - exec.go: Running commands in containers via docker exec, resolved from devcontainer metadata
 //   - git.go: Worktree detection, creation, deletion, branch validation
+//   - gitfs.go: Read-only git metadata access (HEAD, refs, worktrees) without spawning git
+//   - integrate.go: Rebasing/merging worktree branches into their base branch
 //   - tmux_ops.go: Session management, credential injection
 //   - types.go: Type definitions
+//   - worktree_ops.go: Worktree lock, unlock and rename/move
 //
 // # Container Identification
 //
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 // CheckCLI verifies the devcontainer CLI is installed
@@ -24,15 +26,16 @@ func CheckCLI() error {
 // Up starts the devcontainer for a project
 // Returns error if it fails
 func Up(projectPath string) error {
+	forgetExecTarget(projectPath)
 	args := []string{"up", "--workspace-folder", projectPath}
 
-	// For worktrees, mount the main repo's .git directory at the expected host path
+	// For worktrees, mount the shared git directory (the main repo's .git or the bare
+	// repository) at the expected host path
 	// This allows git to find the gitdir referenced in the worktree's .git file
 	wtInfo := IsGitWorktree(projectPath)
 	if wtInfo != nil && !wtInfo.IsMain {
-		mainGitDir := filepath.Join(wtInfo.MainRepo, ".git")
 		args = append(args, "--mount",
-			fmt.Sprintf("type=bind,source=%s,target=%s", mainGitDir, mainGitDir))
+			fmt.Sprintf("type=bind,source=%s,target=%s", wtInfo.CommonDir, wtInfo.CommonDir))
 	}
 
 	cmd := exec.Command("devcontainer", args...)
@@ -65,6 +68,7 @@ func findContainerByPath(projectPath string, runningOnly bool) (string, error) {
 // Stop stops the devcontainer by finding and stopping its Docker container
 // It waits for the container to fully exit before returning
 func Stop(projectPath string) error {
+	forgetExecTarget(projectPath)
 	containerID, err := findContainerByPath(projectPath, true)
 	if err != nil {
 		return err
@@ -83,6 +87,27 @@ func Stop(projectPath string) error {
 	return waitForContainerExit(containerID, 30*time.Second)
 }
 
+// RemoveContainer removes all stopped Docker containers for a project.
+// Running containers must be stopped first; returns nil if no container exists.
+func RemoveContainer(projectPath string) error {
+	forgetExecTarget(projectPath)
+	output, err := findContainerByPath(projectPath, false)
+	if err != nil {
+		return err
+	}
+	containerIDs := strings.Fields(output)
+	if len(containerIDs) == 0 {
+		return nil
+	}
+	rmCmd := exec.Command("docker", append([]string{"rm"}, containerIDs...)...)
+	var stderr bytes.Buffer
+	rmCmd.Stderr = &stderr
+	if err := rmCmd.Run(); err != nil {
+		return fmt.Errorf("failed to remove container: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 // waitForContainerExit polls docker until the container reaches exited state
 func waitForContainerExit(containerID string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
@@ -106,6 +131,7 @@ func waitForContainerExit(containerID string, timeout time.Duration) error {
 
 // Restart restarts the devcontainer
 func Restart(projectPath string) error {
+	forgetExecTarget(projectPath)
 	containerID, err := findContainerByPath(projectPath, false)
 	if err != nil {
 		return err
@@ -130,8 +156,10 @@ func GetContainerStatus(projectPath string) (ContainerStatus, string) {
 		return StatusUnknown, ""
 	}
 	if containerID != "" {
+		forgetStaleExecTarget(projectPath, containerID)
 		return StatusRunning, containerID
 	}
+	forgetExecTarget(projectPath)
 
 	// Check stopped containers
 	cmd := exec.Command("docker", "ps", "-a", "-q",
@@ -163,12 +191,17 @@ func GetAllInstancesStatus(instances []ContainerInstance) []ContainerInstanceWit
 			// Use path-based status check since each worktree has a unique path
 			status, containerID := GetContainerStatus(instance.Path)
 			sessionCount := 0
+			var agentStates map[string]tmux.AgentState
 
-			// Only count sessions if container is running
+			// Only inspect sessions if container is running
 			if status == StatusRunning {
-				sessions, err := ListTmuxSessions(instance.Path)
+				panes, err := CaptureSessionPanes(instance.Path, constants.PanePreviewLines)
 				if err == nil {
-					sessionCount = len(sessions)
+					sessionCount = len(panes)
+					agentStates = make(map[string]tmux.AgentState, len(panes))
+					for name, pane := range panes {
+						agentStates[name] = pane.AgentState()
+					}
 				}
 			}
 
@@ -177,6 +210,7 @@ func GetAllInstancesStatus(instances []ContainerInstance) []ContainerInstanceWit
 				Status:            status,
 				ContainerID:       containerID,
 				SessionCount:      sessionCount,
+				AgentStates:       agentStates,
 			}
 		}(i, inst)
 	}
@@ -186,31 +220,23 @@ func GetAllInstancesStatus(instances []ContainerInstance) []ContainerInstanceWit
 }
 
 // ExecInteractive executes a command inside the devcontainer interactively
-// This replaces the current process with the devcontainer exec
+// This replaces the current process with docker exec (or devcontainer exec)
 func ExecInteractive(projectPath string, args []string) error {
-	devcontainerPath, err := exec.LookPath("devcontainer")
-	if err != nil {
-		return err
+	cmd := containerCommand(projectPath, true, args...)
+	if cmd.Err != nil {
+		return cmd.Err // e.g. docker or the devcontainer CLI is not installed
 	}
-
-	cmdArgs := []string{"devcontainer", "exec", "--workspace-folder", projectPath}
-	cmdArgs = append(cmdArgs, args...)
-
-	// Replace current process with devcontainer exec
-	return syscall.Exec(devcontainerPath, cmdArgs, os.Environ())
+	return syscall.Exec(cmd.Path, cmd.Args, os.Environ())
 }
 
 // execInContainer runs a command inside the devcontainer and returns its output
 func execInContainer(projectPath string, args ...string) ([]byte, error) {
-	cmdArgs := append([]string{"exec", "--workspace-folder", projectPath}, args...)
-	cmd := exec.Command("devcontainer", cmdArgs...)
-	return cmd.Output()
+	return containerCommand(projectPath, false, args...).Output()
 }
 
 // execInContainerWithStderr runs a command inside the devcontainer and captures stderr for errors
 func execInContainerWithStderr(projectPath string, errPrefix string, args ...string) error {
-	cmdArgs := append([]string{"exec", "--workspace-folder", projectPath}, args...)
-	cmd := exec.Command("devcontainer", cmdArgs...)
+	cmd := containerCommand(projectPath, false, args...)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
@@ -0,0 +1,311 @@
+package devcontainer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// metadataLabel is the label the devcontainer CLI stores the merged
+// devcontainer.json metadata (features, image and config) in
+const metadataLabel = "devcontainer.metadata"
+
+// execTarget is everything needed to run a command in a devcontainer with
+// docker exec, as devcontainer exec would: the container, the remote user,
+// the workspace folder and the remoteEnv. Unlike devcontainer exec, it doesn't
+// run the userEnvProbe, so variables set only by the user's shell profile (such
+// as PATH additions in ~/.profile) are missing from non-interactive commands;
+// tmux panes start login shells and pick them up there.
+type execTarget struct {
+	containerID string
+	user        string
+	workDir     string
+	env         map[string]string
+}
+
+// args builds the docker exec arguments for a command, allocating a TTY when interactive
+func (t execTarget) args(interactive bool, command []string) []string {
+	args := []string{"exec"}
+	if interactive {
+		args = append(args, "-it")
+	}
+	if t.user != "" {
+		args = append(args, "-u", t.user)
+	}
+	if t.workDir != "" {
+		args = append(args, "-w", t.workDir)
+	}
+	env := t.env
+	if term := os.Getenv("TERM"); interactive && term != "" && env["TERM"] == "" {
+		env = make(map[string]string, len(t.env)+1)
+		for k, v := range t.env {
+			env[k] = v
+		}
+		env["TERM"] = term
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+env[k])
+	}
+	args = append(args, t.containerID)
+	return append(args, command...)
+}
+
+// execTargets caches resolved targets by project path, so only the first exec
+// into a container pays for docker inspect. Containers whose metadata can't be
+// resolved are remembered too, so they don't pay for docker ps on every exec.
+var execTargets = struct {
+	sync.Mutex
+	byPath map[string]execTarget
+	failed map[string]string // Project path -> ID of the container that couldn't be resolved
+}{byPath: make(map[string]execTarget), failed: make(map[string]string)}
+
+// forgetExecTarget drops the cached target for a project whose container was
+// stopped, restarted or recreated
+func forgetExecTarget(projectPath string) {
+	execTargets.Lock()
+	delete(execTargets.byPath, projectPath)
+	delete(execTargets.failed, projectPath)
+	execTargets.Unlock()
+}
+
+// forgetStaleExecTarget drops the cached target for a project if its container
+// has since been replaced (docker ps reports short IDs, inspect full ones)
+func forgetStaleExecTarget(projectPath, containerID string) {
+	execTargets.Lock()
+	if target, ok := execTargets.byPath[projectPath]; ok && !strings.HasPrefix(target.containerID, containerID) {
+		delete(execTargets.byPath, projectPath)
+	}
+	if failedID, ok := execTargets.failed[projectPath]; ok && !strings.HasPrefix(failedID, containerID) {
+		delete(execTargets.failed, projectPath)
+	}
+	execTargets.Unlock()
+}
+
+// resolveExecTarget returns the docker exec target for a project's running container
+func resolveExecTarget(projectPath string) (execTarget, error) {
+	execTargets.Lock()
+	target, ok := execTargets.byPath[projectPath]
+	_, failed := execTargets.failed[projectPath]
+	execTargets.Unlock()
+	if ok {
+		return target, nil
+	}
+	if failed {
+		return execTarget{}, fmt.Errorf("container metadata could not be resolved")
+	}
+
+	if configSetsWorkspaceFolder(projectPath) {
+		return execTarget{}, fmt.Errorf("workspaceFolder is set in devcontainer.json")
+	}
+	status, containerID := GetContainerStatus(projectPath)
+	if status != StatusRunning || containerID == "" || strings.ContainsAny(containerID, " \n") {
+		return execTarget{}, fmt.Errorf("no single running container found for project")
+	}
+	output, err := exec.Command("docker", "inspect", "--format", "{{json .}}", containerID).Output()
+	if err != nil {
+		return execTarget{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	target, err = parseExecTarget(output, projectPath)
+	if err != nil {
+		// Retried once the container is replaced
+		execTargets.Lock()
+		execTargets.failed[projectPath] = containerID
+		execTargets.Unlock()
+		return execTarget{}, err
+	}
+
+	execTargets.Lock()
+	execTargets.byPath[projectPath] = target
+	execTargets.Unlock()
+	return target, nil
+}
+
+// configSetsWorkspaceFolder reports whether the project's devcontainer.json
+// overrides workspaceFolder, which isn't recorded on the container and so
+// can only be honoured by the devcontainer CLI
+func configSetsWorkspaceFolder(projectPath string) bool {
+	for _, path := range []string{
+		filepath.Join(projectPath, ".devcontainer", "devcontainer.json"),
+		filepath.Join(projectPath, ".devcontainer.json"),
+	} {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			return bytes.Contains(data, []byte(`"workspaceFolder"`))
+		}
+	}
+	return false
+}
+
+// containerInspect is the subset of docker inspect output needed to build an exec target
+type containerInspect struct {
+	ID     string `json:"Id"`
+	Config struct {
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	Mounts []struct {
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+	} `json:"Mounts"`
+}
+
+// metadataEntry is one entry of the devcontainer.metadata label
+type metadataEntry struct {
+	RemoteUser    string             `json:"remoteUser"`
+	ContainerUser string             `json:"containerUser"`
+	RemoteEnv     map[string]*string `json:"remoteEnv"`
+}
+
+// parseExecTarget builds an exec target from docker inspect output: the
+// workspace folder is where the project is mounted, and the user and env
+// come from the metadata label, later entries overriding earlier ones
+func parseExecTarget(inspectJSON []byte, projectPath string) (execTarget, error) {
+	var info containerInspect
+	if err := json.Unmarshal(inspectJSON, &info); err != nil {
+		return execTarget{}, fmt.Errorf("failed to parse container info: %w", err)
+	}
+
+	target := execTarget{containerID: info.ID}
+	for _, m := range info.Mounts {
+		// Docker Desktop on macOS reports bind sources under /host_mnt
+		if m.Source == projectPath || m.Source == "/host_mnt"+projectPath {
+			target.workDir = m.Destination
+			break
+		}
+	}
+	if target.workDir == "" {
+		return execTarget{}, fmt.Errorf("workspace mount not found")
+	}
+
+	label, ok := info.Config.Labels[metadataLabel]
+	if !ok {
+		return execTarget{}, fmt.Errorf("container has no %s label", metadataLabel)
+	}
+	entries, err := parseMetadata(label)
+	if err != nil {
+		return execTarget{}, err
+	}
+
+	containerEnv := envMap(info.Config.Env)
+	remoteEnv := make(map[string]*string)
+	var remoteUser, containerUser string
+	for _, e := range entries {
+		if e.RemoteUser != "" {
+			remoteUser = e.RemoteUser
+		}
+		if e.ContainerUser != "" {
+			containerUser = e.ContainerUser
+		}
+		for k, v := range e.RemoteEnv {
+			remoteEnv[k] = v
+		}
+	}
+	target.user = remoteUser
+	if target.user == "" {
+		target.user = containerUser
+	}
+
+	for k, v := range remoteEnv {
+		if v == nil {
+			continue
+		}
+		value, err := substituteEnv(*v, containerEnv)
+		if err != nil {
+			return execTarget{}, fmt.Errorf("remoteEnv %s: %w", k, err)
+		}
+		if target.env == nil {
+			target.env = make(map[string]string)
+		}
+		target.env[k] = value
+	}
+	return target, nil
+}
+
+// parseMetadata decodes the metadata label, which is a JSON array of entries
+// or, for images built by older CLIs, a single entry
+func parseMetadata(label string) ([]metadataEntry, error) {
+	var entries []metadataEntry
+	if err := json.Unmarshal([]byte(label), &entries); err == nil {
+		return entries, nil
+	}
+	var entry metadataEntry
+	if err := json.Unmarshal([]byte(label), &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s label: %w", metadataLabel, err)
+	}
+	return []metadataEntry{entry}, nil
+}
+
+// envMap turns KEY=VALUE pairs into a map
+func envMap(pairs []string) map[string]string {
+	env := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if k, v, ok := strings.Cut(pair, "="); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
+
+// envVariablePattern matches ${...} substitutions in devcontainer.json values
+var envVariablePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// substituteEnv expands ${containerEnv:NAME[:default]} and ${localEnv:NAME[:default]}
+// in a remoteEnv value; any other variable can't be resolved here
+func substituteEnv(value string, containerEnv map[string]string) (string, error) {
+	var unresolved string
+	result := envVariablePattern.ReplaceAllStringFunc(value, func(match string) string {
+		parts := strings.SplitN(match[2:len(match)-1], ":", 3)
+		if len(parts) < 2 {
+			unresolved = match
+			return match
+		}
+		var v string
+		var ok bool
+		switch parts[0] {
+		case "containerEnv":
+			v, ok = containerEnv[parts[1]]
+		case "localEnv", "env":
+			v, ok = os.LookupEnv(parts[1])
+		default:
+			unresolved = match
+			return match
+		}
+		if !ok && len(parts) == 3 {
+			v = parts[2]
+		}
+		return v
+	})
+	if unresolved != "" {
+		return "", fmt.Errorf("cannot resolve %s", unresolved)
+	}
+	return result, nil
+}
+
+// containerCommand builds a command that runs args in the project's
+// devcontainer: docker exec when the container's metadata can be resolved,
+// otherwise the (much slower to start) devcontainer CLI
+func containerCommand(projectPath string, interactive bool, args ...string) *exec.Cmd {
+	if target, err := resolveExecTarget(projectPath); err == nil {
+		return exec.Command("docker", target.args(interactive, args)...)
+	}
+	cmdArgs := append([]string{"exec", "--workspace-folder", projectPath}, args...)
+	return exec.Command("devcontainer", cmdArgs...)
+}
+
+// InteractiveCommand builds a command that runs args in the project's
+// devcontainer attached to the terminal, for use with tea.ExecProcess
+func InteractiveCommand(projectPath string, args ...string) *exec.Cmd {
+	return containerCommand(projectPath, true, args...)
+}
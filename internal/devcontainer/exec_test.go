@@ -0,0 +1,198 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// inspectJSON builds docker inspect output with the given metadata label and env
+func inspectJSON(t *testing.T, metadata string, env []string) []byte {
+	t.Helper()
+	info := map[string]any{
+		"Id": "abc123def456789",
+		"Config": map[string]any{
+			"Env":    env,
+			"Labels": map[string]string{"devcontainer.local_folder": "/home/me/app", metadataLabel: metadata},
+		},
+		"Mounts": []map[string]string{
+			{"Source": "/home/me/app/.git", "Destination": "/home/me/app/.git"},
+			{"Source": "/home/me/app", "Destination": "/workspaces/app"},
+		},
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestParseExecTarget(t *testing.T) {
+	metadata := `[
+		{"id": "ghcr.io/devcontainers/features/node:1", "remoteEnv": {"NODE_ENV": "development"}},
+		{"containerUser": "root", "remoteUser": "node", "remoteEnv": {"PATH": "${containerEnv:PATH}:/home/node/bin"}},
+		{"remoteUser": "vscode", "remoteEnv": {"NODE_ENV": null, "EDITOR": "${containerEnv:EDITOR:vi}"}}
+	]`
+	target, err := parseExecTarget(inspectJSON(t, metadata, []string{"PATH=/usr/bin:/bin"}), "/home/me/app")
+	if err != nil {
+		t.Fatalf("parseExecTarget() error = %v", err)
+	}
+	want := execTarget{
+		containerID: "abc123def456789",
+		user:        "vscode",
+		workDir:     "/workspaces/app",
+		env:         map[string]string{"PATH": "/usr/bin:/bin:/home/node/bin", "EDITOR": "vi"},
+	}
+	if !reflect.DeepEqual(target, want) {
+		t.Errorf("parseExecTarget() = %+v, want %+v", target, want)
+	}
+}
+
+func TestParseExecTarget_ContainerUserAndSingleEntry(t *testing.T) {
+	target, err := parseExecTarget(inspectJSON(t, `{"containerUser": "dev"}`, nil), "/home/me/app")
+	if err != nil {
+		t.Fatalf("parseExecTarget() error = %v", err)
+	}
+	if target.user != "dev" || target.env != nil {
+		t.Errorf("parseExecTarget() = %+v, want user dev and no env", target)
+	}
+}
+
+func TestParseExecTarget_Unresolvable(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		path     string
+	}{
+		{"no workspace mount", `[]`, "/elsewhere"},
+		{"bad label", `not json`, "/home/me/app"},
+		{"unknown variable", `[{"remoteEnv": {"ROOT": "${containerWorkspaceFolder}"}}]`, "/home/me/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseExecTarget(inspectJSON(t, tt.metadata, nil), tt.path); err == nil {
+				t.Error("parseExecTarget() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestSubstituteEnv(t *testing.T) {
+	t.Setenv("CQ_TEST_LOCAL", "host")
+	containerEnv := map[string]string{"HOME": "/home/node"}
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{"${containerEnv:HOME}/bin", "/home/node/bin"},
+		{"${containerEnv:MISSING}", ""},
+		{"${containerEnv:MISSING:a:b}", "a:b"},
+		{"${localEnv:CQ_TEST_LOCAL}-${containerEnv:HOME}", "host-/home/node"},
+	}
+	for _, tt := range tests {
+		got, err := substituteEnv(tt.value, containerEnv)
+		if err != nil || got != tt.want {
+			t.Errorf("substituteEnv(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestExecTargetArgs(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	target := execTarget{
+		containerID: "abc",
+		user:        "node",
+		workDir:     "/workspaces/app",
+		env:         map[string]string{"B": "2", "A": "1"},
+	}
+
+	got := target.args(false, []string{"tmux", "ls"})
+	want := []string{"exec", "-u", "node", "-w", "/workspaces/app", "-e", "A=1", "-e", "B=2", "abc", "tmux", "ls"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("args(false) = %v, want %v", got, want)
+	}
+
+	got = target.args(true, []string{"tmux", "attach-session"})
+	want = []string{"exec", "-it", "-u", "node", "-w", "/workspaces/app",
+		"-e", "A=1", "-e", "B=2", "-e", "TERM=xterm-256color", "abc", "tmux", "attach-session"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("args(true) = %v, want %v", got, want)
+	}
+	if _, ok := target.env["TERM"]; ok {
+		t.Error("args(true) modified the target's env")
+	}
+}
+
+func TestConfigSetsWorkspaceFolder(t *testing.T) {
+	dir := t.TempDir()
+	if configSetsWorkspaceFolder(dir) {
+		t.Error("configSetsWorkspaceFolder() = true without a config")
+	}
+	configDir := filepath.Join(dir, ".devcontainer")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(configDir, "devcontainer.json")
+	if err := os.WriteFile(config, []byte(`{"image": "node"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if configSetsWorkspaceFolder(dir) {
+		t.Error("configSetsWorkspaceFolder() = true for a config without workspaceFolder")
+	}
+	if err := os.WriteFile(config, []byte(`{"image": "node", "workspaceFolder": "/src"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !configSetsWorkspaceFolder(dir) {
+		t.Error("configSetsWorkspaceFolder() = false for a config with workspaceFolder")
+	}
+}
+
+func TestResolveExecTarget_CachesFailure(t *testing.T) {
+	// A running container without devcontainer metadata; every docker call is logged
+	dir := t.TempDir()
+	log := filepath.Join(dir, "calls")
+	script := `#!/bin/sh
+echo "$1" >> "` + log + `"
+case "$1" in
+ps) echo c0ffee ;;
+inspect) echo '{"Id":"c0ffee","Config":{"Labels":{}},"Mounts":[]}' ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	project := t.TempDir()
+	t.Cleanup(func() { forgetExecTarget(project) })
+
+	for i := 0; i < 3; i++ {
+		if _, err := resolveExecTarget(project); err == nil {
+			t.Fatal("resolveExecTarget() error = nil, want unresolvable metadata")
+		}
+	}
+	if calls, _ := os.ReadFile(log); string(calls) != "ps\ninspect\n" {
+		t.Errorf("docker calls = %q, want one ps and one inspect", calls)
+	}
+
+	// A restart forgets the failure
+	forgetExecTarget(project)
+	resolveExecTarget(project)
+	if calls, _ := os.ReadFile(log); strings.Count(string(calls), "inspect") != 2 {
+		t.Errorf("docker calls = %q, want the container inspected again", calls)
+	}
+}
+
+func TestExecInteractive_NotInstalled(t *testing.T) {
+	t.Setenv("PATH", t.TempDir()) // Neither docker nor the devcontainer CLI
+
+	err := ExecInteractive(t.TempDir(), []string{"true"})
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("ExecInteractive() error = %v, want %v", err, exec.ErrNotFound)
+	}
+}
@@ -6,123 +6,52 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/constants"
 )
 
 // IsGitWorktree checks if the given path is a git worktree and returns its info
 // Returns nil if the path is not a git worktree or not a git repository
 func IsGitWorktree(path string) *WorktreeInfo {
-	gitPath := filepath.Join(path, ".git")
-	info, err := os.Stat(gitPath)
-	if err != nil {
+	g, ok := openGitDirs(path)
+	if !ok {
 		return nil // Not a git repository
 	}
-
-	if info.IsDir() {
-		// It's a regular git repository (main worktree)
-		branch := getGitBranch(path)
-		wt := newMainWorktreeInfo(path, branch)
-		return &wt
-	}
-
-	// .git is a file - this is a worktree
-	// Read the gitdir from the file
-	content, err := os.ReadFile(gitPath)
-	if err != nil {
-		return nil
-	}
-
-	// Parse: "gitdir: /path/to/.git/worktrees/name"
-	line := strings.TrimSpace(string(content))
-	if !strings.HasPrefix(line, "gitdir: ") {
-		return nil
+	if g.bare && g.gitDir == g.commonDir {
+		return nil // A bare repository has no working tree
 	}
-	gitDir := strings.TrimPrefix(line, "gitdir: ")
 
-	// Find the main repo (go up from .git/worktrees/<name> to .git, then parent)
-	// gitDir is like /path/to/main/.git/worktrees/feature-x
-	mainGitDir := filepath.Dir(filepath.Dir(gitDir)) // Go up to .git
-	mainRepo := filepath.Dir(mainGitDir)             // Go up to main repo
-
-	branch := getGitBranch(path)
-
-	return &WorktreeInfo{
-		Path:     path,
-		Branch:   branch,
-		MainRepo: mainRepo,
-		GitDir:   gitDir,
-		IsMain:   false,
-	}
+	// The main worktree owns the shared .git directory; linked worktrees have a .git
+	// file pointing at .git/worktrees/<name> (or <bare repo>/worktrees/<name>)
+	wt := g.worktreeInfo(path)
+	return &wt
 }
 
 // getGitBranch returns the current branch name for a git repository
 func getGitBranch(repoPath string) string {
-	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
-	if err != nil {
+	g, ok := openGitDirs(repoPath)
+	if !ok {
 		return constants.DefaultBranchUnknown
 	}
-	return strings.TrimSpace(string(output))
+	return g.branchName()
 }
 
 // ListWorktrees returns all worktrees for a repository (including the main one)
 func ListWorktrees(repoPath string) ([]WorktreeInfo, error) {
-	// Run git worktree list --porcelain
-	cmd := exec.Command("git", "-C", repoPath, "worktree", "list", "--porcelain")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list worktrees: %w", err)
-	}
-
-	var worktrees []WorktreeInfo
-	var current WorktreeInfo
-	isFirst := true
-
-	// Helper to finalize and append the current worktree
-	appendWorktree := func() {
-		if current.Path == "" {
-			return
-		}
-		if isFirst {
-			worktrees = append(worktrees, newMainWorktreeInfo(current.Path, current.Branch))
-		} else {
-			worktrees = append(worktrees, newBranchWorktreeInfo(current.Path, current.Branch, repoPath))
-		}
-		isFirst = false
-	}
-
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			appendWorktree()
-			current = WorktreeInfo{}
-			continue
-		}
-
-		if strings.HasPrefix(line, "worktree ") {
-			current.Path = strings.TrimPrefix(line, "worktree ")
-		} else if strings.HasPrefix(line, "branch refs/heads/") {
-			current.Branch = strings.TrimPrefix(line, "branch refs/heads/")
-		} else if strings.HasPrefix(line, "HEAD ") {
-			// Detached HEAD - use short SHA as branch name
-			if current.Branch == "" {
-				sha := strings.TrimPrefix(line, "HEAD ")
-				if len(sha) > constants.SHATruncateLength {
-					current.Branch = sha[:constants.SHATruncateLength]
-				} else {
-					current.Branch = sha
-				}
-			}
-		}
+	g, ok := openGitDirs(repoPath)
+	if !ok {
+		return nil, fmt.Errorf("failed to list worktrees: not a git repository: %s", repoPath)
 	}
+	return listWorktreesFromDisk(g), nil
+}
 
-	// Handle last worktree if no trailing newline
-	appendWorktree()
-
-	return worktrees, nil
+// localBranchExists returns true if a local branch exists in the repository
+func localBranchExists(repoPath, branchName string) bool {
+	g, ok := openGitDirs(repoPath)
+	return ok && g.refExists("refs/heads/"+branchName)
 }
 
 // GetMainRepo finds the main repository path from any worktree path
@@ -134,11 +63,25 @@ func GetMainRepo(worktreePath string) (string, error) {
 	return info.MainRepo, nil
 }
 
+// worktreePathFor returns the directory used for a branch's worktree: a sibling of the
+// main repo named repo-branchname, or branchname inside the project directory when the
+// bare repository is hidden in it (project/.bare).
+// "/" is replaced with "-" to avoid creating nested directories for hierarchical branches
+func worktreePathFor(repo WorktreeInfo, branchName string) string {
+	safeBranchName := strings.ReplaceAll(branchName, "/", "-")
+	parent := filepath.Dir(repo.MainRepo)
+	if repo.Bare && strings.HasPrefix(filepath.Base(repo.MainRepo), ".") {
+		return filepath.Join(parent, safeBranchName)
+	}
+	return filepath.Join(parent, repo.RepoName()+"-"+safeBranchName)
+}
+
 // CreateWorktree creates a new git worktree with a new branch
-// Returns the path to the new worktree directory and any push warning
-func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath string, pushWarning string, err error) {
+// Returns the path to the new worktree directory and a warning describing any
+// non-fatal failure (push, sparse-checkout or submodule update)
+func CreateWorktree(repoPath, branchName string, autoPush bool, cfg WorktreeConfig) (worktreePath string, warning string, err error) {
 	// Validate branch name
-	if err := ValidateBranchName(branchName); err != nil {
+	if err := ValidateBranchName(branchName, cfg.ReservedBranchPatterns()); err != nil {
 		return "", "", err
 	}
 
@@ -156,11 +99,7 @@ func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath st
 	pruneCmd := exec.Command("git", "-C", mainRepo, "worktree", "prune")
 	_ = pruneCmd.Run() // Ignore errors - prune is best-effort cleanup
 
-	// Create worktree path as sibling directory: repo-branchname
-	// Replace "/" with "-" to avoid creating nested directories for hierarchical branches
-	repoName := filepath.Base(mainRepo)
-	safeBranchName := strings.ReplaceAll(branchName, "/", "-")
-	wtPath := filepath.Join(filepath.Dir(mainRepo), repoName+"-"+safeBranchName)
+	wtPath := worktreePathFor(*wtInfo, branchName)
 
 	// Check if worktree already exists
 	if _, err := os.Stat(wtPath); err == nil {
@@ -168,22 +107,47 @@ func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath st
 	}
 
 	// Check if branch already exists
-	checkBranch := exec.Command("git", "-C", mainRepo, "rev-parse", "--verify", branchName)
-	branchExists := checkBranch.Run() == nil
+	branchExists := localBranchExists(mainRepo, branchName)
+
+	// New branches start from the main worktree's HEAD, remember it as the base
+	baseBranch := getGitBranch(mainRepo)
 
 	// Create the worktree - use existing branch or create new one
-	var cmd *exec.Cmd
+	// Sparse worktrees are created empty and populated once the cones are set
+	args := []string{"-C", mainRepo, "worktree", "add"}
+	if len(cfg.SparseCheckout) > 0 {
+		args = append(args, "--no-checkout")
+	}
 	if branchExists {
-		cmd = exec.Command("git", "-C", mainRepo, "worktree", "add", wtPath, branchName)
+		args = append(args, wtPath, branchName)
 	} else {
-		cmd = exec.Command("git", "-C", mainRepo, "worktree", "add", "-b", branchName, wtPath)
+		args = append(args, "-b", branchName, wtPath)
 	}
+	cmd := exec.Command("git", args...)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
 		return "", "", fmt.Errorf("failed to create worktree: %s", stderr.String())
 	}
+	if !branchExists {
+		recordBaseBranch(mainRepo, branchName, baseBranch)
+	}
+
+	var warnings []string
+	if len(cfg.SparseCheckout) > 0 {
+		if err := applySparseCheckout(wtPath, cfg.SparseCheckout); err != nil {
+			// Fall back to a full checkout rather than leaving the worktree empty
+			warnings = append(warnings, err.Error()+" (checked out the full repository)")
+			if err := populateWorktree(wtPath); err != nil {
+				return wtPath, "", err
+			}
+		}
+	}
+
+	if err := updateSubmodules(wtPath, cfg.Submodules); err != nil {
+		warnings = append(warnings, err.Error())
+	}
 
 	// Push new branch upstream with tracking if enabled and branch is new
 	if autoPush && !branchExists {
@@ -191,12 +155,12 @@ func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath st
 		var pushStderr bytes.Buffer
 		pushCmd.Stderr = &pushStderr
 		if err := pushCmd.Run(); err != nil {
-			pushWarning = fmt.Sprintf("Branch created but push failed: %s",
-				strings.TrimSpace(pushStderr.String()))
+			warnings = append(warnings, fmt.Sprintf("Branch created but push failed: %s",
+				strings.TrimSpace(pushStderr.String())))
 		}
 	}
 
-	return wtPath, pushWarning, nil
+	return wtPath, strings.Join(warnings, "; "), nil
 }
 
 // RemoveWorktree removes a git worktree
@@ -209,6 +173,10 @@ func RemoveWorktree(worktreePath string, mainRepoPath ...string) error {
 			return fmt.Errorf("failed to stop container: %w", err)
 		}
 	}
+	// The container is gone, so are its credentials
+	if err := auth.CleanupCredentialFile(worktreePath); err != nil {
+		return err
+	}
 
 	wtInfo := IsGitWorktree(worktreePath)
 
@@ -242,28 +210,190 @@ func RemoveWorktree(worktreePath string, mainRepoPath ...string) error {
 	return nil
 }
 
-// ValidateBranchName checks if a branch name is valid for git
-func ValidateBranchName(name string) error {
+// gitOutput runs a git command in repoPath and returns its trimmed stdout
+func gitOutput(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitRun runs a git command in repoPath and includes stderr in the returned error
+func gitRun(repoPath, errPrefix string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %s", errPrefix, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// DefaultBranch returns the repository's default branch name.
+// Uses origin/HEAD when available, falling back to a local main or master branch.
+// Returns an empty string if no default branch can be determined.
+func DefaultBranch(repoPath string) string {
+	g, ok := openGitDirs(repoPath)
+	if !ok {
+		return ""
+	}
+	if ref, ok := g.symbolicRef("refs/remotes/origin/HEAD"); ok {
+		return strings.TrimPrefix(ref, "refs/remotes/origin/")
+	}
+	for _, name := range constants.ReservedBranchNames {
+		if g.refExists("refs/heads/" + name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// GetWorktreeStatus inspects a worktree for uncommitted changes, unpushed commits
+// and whether its branch has been merged into the repository's default branch
+func GetWorktreeStatus(wt WorktreeInfo) (WorktreeStatus, error) {
+	status := WorktreeStatus{Branch: wt.Branch}
+
+	if _, err := os.Stat(wt.Path); err != nil {
+		return status, fmt.Errorf("worktree directory not found: %s", wt.Path)
+	}
+
+	// Uncommitted changes (porcelain format: "XY path"). Read untrimmed, since the
+	// status columns can start with a space, and NUL-separated so paths are unquoted.
+	output, err := exec.Command("git", "-C", wt.Path, "status", "--porcelain", "-z").Output()
+	if err != nil {
+		return status, fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	status.ChangedFiles = parsePorcelainPaths(string(output))
+
+	// Unpushed commits: compare against upstream if tracked, otherwise against all remotes
+	if _, err := gitOutput(wt.Path, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"); err == nil {
+		status.HasUpstream = true
+		if count, err := gitOutput(wt.Path, "rev-list", "--count", "@{u}..HEAD"); err == nil {
+			status.UnpushedCommits, _ = strconv.Atoi(count)
+		}
+	} else if remotes, err := gitOutput(wt.Path, "remote"); err == nil && remotes != "" {
+		if count, err := gitOutput(wt.Path, "rev-list", "--count", "HEAD", "--not", "--remotes"); err == nil {
+			status.UnpushedCommits, _ = strconv.Atoi(count)
+		}
+	}
+
+	// Merged into the default branch
+	status.BaseBranch = BaseBranch(wt)
+	if status.BaseBranch != "" && status.BaseBranch != wt.Branch {
+		_, err := gitOutput(wt.Path, "merge-base", "--is-ancestor", "HEAD", "refs/heads/"+status.BaseBranch)
+		status.Merged = err == nil
+	}
+
+	return status, nil
+}
+
+// parsePorcelainPaths returns the paths in `git status --porcelain -z` output
+func parsePorcelainPaths(output string) []string {
+	var paths []string
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) <= 3 {
+			continue
+		}
+		paths = append(paths, entry[3:])
+		// Renames and copies, staged or not, are followed by their original path
+		if strings.ContainsAny(entry[:2], "RC") {
+			i++
+		}
+	}
+	return paths
+}
+
+// StashWorktreeChanges stashes all uncommitted changes (including untracked files).
+// The stash is stored in the shared repository, so it survives worktree removal.
+func StashWorktreeChanges(worktreePath, message string) error {
+	return gitRun(worktreePath, "failed to stash changes",
+		"stash", "push", "--include-untracked", "-m", message)
+}
+
+// CommitAndPushWorktree commits all uncommitted changes and pushes the branch upstream
+func CommitAndPushWorktree(worktreePath, branch, message string) error {
+	if branch == "" {
+		return fmt.Errorf("cannot push a detached HEAD, check out a branch first")
+	}
+	if err := gitRun(worktreePath, "failed to stage changes", "add", "-A"); err != nil {
+		return err
+	}
+	// Only commit if something is staged (exit code 1 means there are staged changes)
+	if _, err := gitOutput(worktreePath, "diff", "--cached", "--quiet"); err != nil {
+		if err := gitRun(worktreePath, "failed to commit changes", "commit", "-m", message); err != nil {
+			return err
+		}
+	}
+	return gitRun(worktreePath, "failed to push branch", "push", "-u", "origin", branch)
+}
+
+// DeleteBranch deletes a local branch and optionally its remote counterpart.
+// Remote deletion failures are returned as a warning since the remote branch may not exist.
+func DeleteBranch(mainRepo, branch string, deleteRemote bool) (warning string, err error) {
+	// Look up the tracked remote before the local branch (and its config) is gone
+	remote, _ := gitOutput(mainRepo, "config", "--get", "branch."+branch+".remote")
+	if remote == "" || remote == "." {
+		remote = "origin"
+	}
+
+	if err := gitRun(mainRepo, "failed to delete branch", "branch", "-D", branch); err != nil {
+		return "", err
+	}
+
+	if deleteRemote {
+		if err := gitRun(mainRepo, "failed to delete remote branch", "push", remote, "--delete", branch); err != nil {
+			return err.Error(), nil
+		}
+	}
+	return "", nil
+}
+
+// ValidateBranchName checks if a branch name is valid for git, following the
+// rules of "git check-ref-format --branch", and that it does not match any of
+// the reserved branch patterns
+func ValidateBranchName(name string, reserved []string) error {
 	if name == "" {
 		return fmt.Errorf("branch name cannot be empty")
 	}
-	if constants.IsReservedBranchName(name) {
+	if constants.MatchesReservedBranch(name, reserved) {
 		return fmt.Errorf("'%s' is a reserved branch name", name)
 	}
-	// Git branch name rules (simplified)
 	if strings.HasPrefix(name, "-") {
 		return fmt.Errorf("branch name cannot start with '-'")
 	}
+	if name == "@" || name == "HEAD" {
+		return fmt.Errorf("'%s' is not a valid branch name", name)
+	}
 	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
 		return fmt.Errorf("branch name cannot start or end with '.'")
 	}
+	if strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") {
+		return fmt.Errorf("branch name cannot start or end with '/'")
+	}
 	if strings.Contains(name, "..") {
 		return fmt.Errorf("branch name cannot contain '..'")
 	}
-	// Only allow alphanumeric, hyphen, underscore, slash for hierarchical branches
+	if strings.Contains(name, "@{") {
+		return fmt.Errorf("branch name cannot contain '@{'")
+	}
 	for _, r := range name {
-		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '/') {
-			return fmt.Errorf("branch name contains invalid character: %c", r)
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("branch name contains invalid character: %q", r)
+		}
+	}
+	for _, component := range strings.Split(name, "/") {
+		if component == "" {
+			return fmt.Errorf("branch name cannot contain '//'")
+		}
+		if strings.HasPrefix(component, ".") {
+			return fmt.Errorf("branch name components cannot start with '.'")
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("branch name components cannot end with '.lock'")
 		}
 	}
 	return nil
@@ -0,0 +1,267 @@
+package devcontainer
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+)
+
+// Read-only git queries are answered by reading the repository metadata on disk
+// (.git, HEAD, refs, packed-refs, worktrees/*) instead of spawning git, which
+// dominates discovery time on large search paths. Mutations still use the CLI.
+
+// maxSymrefDepth limits how many symbolic refs are followed when resolving a ref
+const maxSymrefDepth = 5
+
+// gitDirs locates the metadata directories of a checkout
+type gitDirs struct {
+	gitDir    string // Per-worktree git directory (.git or .git/worktrees/<name>)
+	commonDir string // Shared directory holding refs, packed-refs and worktrees
+	bare      bool   // True if commonDir is a bare repository (no main worktree)
+}
+
+// openGitDirs finds the git directories for a checkout at path, or for a bare
+// repository if path is one. Returns false if path is not a git repository or worktree.
+func openGitDirs(path string) (gitDirs, bool) {
+	gitPath := filepath.Join(path, ".git")
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		// A bare repository ("git clone --bare") is its own git directory
+		if fileExists(filepath.Join(path, "HEAD")) && isBareRepo(path) {
+			return gitDirs{gitDir: path, commonDir: path, bare: true}, true
+		}
+		return gitDirs{}, false
+	}
+
+	if info.IsDir() {
+		return gitDirs{gitDir: gitPath, commonDir: gitPath, bare: isBareRepo(gitPath)}, true
+	}
+
+	// .git is a file - this is a worktree ("gitdir: /path/to/.git/worktrees/name")
+	// or a directory pointing at a bare repository ("gitdir: ./.bare")
+	gitDir, ok := readGitdirFile(gitPath)
+	if !ok {
+		return gitDirs{}, false
+	}
+	commonDir := readCommonDir(gitDir)
+	return gitDirs{gitDir: gitDir, commonDir: commonDir, bare: isBareRepo(commonDir)}, true
+}
+
+// isBareRepo returns true if the git directory's config sets core.bare = true
+func isBareRepo(gitDir string) bool {
+	file, err := os.Open(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	inCore := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inCore = strings.EqualFold(strings.Trim(line, "[] \t"), "core")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if inCore && ok && strings.EqualFold(strings.TrimSpace(key), "bare") {
+			return strings.EqualFold(strings.TrimSpace(value), "true")
+		}
+	}
+	return false
+}
+
+// mainRepo returns the path identifying the repository: the main worktree,
+// or the git directory itself for bare repositories
+func (g gitDirs) mainRepo() string {
+	if g.bare {
+		return g.commonDir
+	}
+	return filepath.Dir(g.commonDir)
+}
+
+// readGitdirFile parses a "gitdir: <path>" file, resolving relative paths
+// against the file's directory
+func readGitdirFile(file string) (string, bool) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+	line := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(line, "gitdir: ") {
+		return "", false
+	}
+	return resolveRelative(filepath.Dir(file), strings.TrimPrefix(line, "gitdir: ")), true
+}
+
+// readCommonDir returns the shared git directory for a worktree's gitdir.
+// Uses the commondir file, falling back to the parent of the worktrees directory.
+func readCommonDir(gitDir string) string {
+	if content, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		return resolveRelative(gitDir, strings.TrimSpace(string(content)))
+	}
+	if filepath.Base(filepath.Dir(gitDir)) == "worktrees" {
+		return filepath.Dir(filepath.Dir(gitDir))
+	}
+	return gitDir
+}
+
+// resolveRelative joins p to base unless it is already absolute
+func resolveRelative(base, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(base, p)
+}
+
+// head reads HEAD and returns the checked out branch, or the commit SHA if detached.
+// Both are empty if HEAD cannot be read.
+func (g gitDirs) head() (branch, sha string) {
+	content, err := os.ReadFile(filepath.Join(g.gitDir, "HEAD"))
+	if err != nil {
+		return "", ""
+	}
+	value := strings.TrimSpace(string(content))
+	if ref, ok := strings.CutPrefix(value, "ref: "); ok {
+		sha, _ = g.resolveRef(ref)
+		return strings.TrimPrefix(ref, "refs/heads/"), sha
+	}
+	return "", value
+}
+
+// resolveRef returns the commit SHA a ref points to, following symbolic refs
+// and falling back to packed-refs
+func (g gitDirs) resolveRef(ref string) (string, bool) {
+	for range maxSymrefDepth {
+		content, err := os.ReadFile(filepath.Join(g.commonDir, filepath.FromSlash(ref)))
+		if err != nil {
+			return g.packedRef(ref)
+		}
+		value := strings.TrimSpace(string(content))
+		target, ok := strings.CutPrefix(value, "ref: ")
+		if !ok {
+			return value, value != ""
+		}
+		ref = target
+	}
+	return "", false
+}
+
+// symbolicRef returns the target of a symbolic ref such as refs/remotes/origin/HEAD
+func (g gitDirs) symbolicRef(ref string) (string, bool) {
+	content, err := os.ReadFile(filepath.Join(g.commonDir, filepath.FromSlash(ref)))
+	if err != nil {
+		return "", false
+	}
+	return strings.CutPrefix(strings.TrimSpace(string(content)), "ref: ")
+}
+
+// refExists returns true if the ref exists as a loose or packed ref
+func (g gitDirs) refExists(ref string) bool {
+	_, ok := g.resolveRef(ref)
+	return ok
+}
+
+// packedRef looks up a ref in the packed-refs file
+func (g gitDirs) packedRef(ref string) (string, bool) {
+	file, err := os.Open(filepath.Join(g.commonDir, "packed-refs"))
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Skip the header and peeled tag lines ("^<sha>")
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		sha, name, ok := strings.Cut(line, " ")
+		if ok && name == ref {
+			return sha, true
+		}
+	}
+	return "", false
+}
+
+// branchName returns the checked out branch, or "HEAD" when detached,
+// matching "git rev-parse --abbrev-ref HEAD"
+func (g gitDirs) branchName() string {
+	branch, sha := g.head()
+	switch {
+	case branch != "":
+		return branch
+	case sha != "":
+		return "HEAD"
+	default:
+		return constants.DefaultBranchUnknown
+	}
+}
+
+// worktreeInfo describes the checkout at path whose git directories are g
+func (g gitDirs) worktreeInfo(path string) WorktreeInfo {
+	branch, sha := g.head()
+	wt := WorktreeInfo{
+		Path:      path,
+		Branch:    branch,
+		MainRepo:  g.mainRepo(),
+		GitDir:    g.gitDir,
+		CommonDir: g.commonDir,
+		IsMain:    !g.bare && g.gitDir == g.commonDir,
+		Bare:      g.bare,
+		Detached:  branch == "" && sha != "",
+		Head:      sha,
+	}
+	if branch == "" && sha == "" {
+		wt.Branch = constants.DefaultBranchUnknown
+	}
+	return wt
+}
+
+// listWorktreesFromDisk reads the main worktree (unless the repository is bare)
+// and every linked worktree registered under <commondir>/worktrees
+func listWorktreesFromDisk(g gitDirs) []WorktreeInfo {
+	var worktrees []WorktreeInfo
+	if !g.bare {
+		mainDirs := gitDirs{gitDir: g.commonDir, commonDir: g.commonDir}
+		worktrees = append(worktrees, mainDirs.worktreeInfo(g.mainRepo()))
+	}
+
+	entries, err := os.ReadDir(filepath.Join(g.commonDir, "worktrees"))
+	if err != nil {
+		return worktrees // No linked worktrees
+	}
+
+	var linked []WorktreeInfo
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		adminDir := filepath.Join(g.commonDir, "worktrees", entry.Name())
+
+		// gitdir holds the path of the worktree's .git file
+		content, err := os.ReadFile(filepath.Join(adminDir, "gitdir"))
+		if err != nil {
+			continue
+		}
+		dotGit := resolveRelative(adminDir, strings.TrimSpace(string(content)))
+
+		wtDirs := gitDirs{gitDir: adminDir, commonDir: g.commonDir, bare: g.bare}
+		wt := wtDirs.worktreeInfo(filepath.Dir(dotGit))
+		if reason, err := os.ReadFile(filepath.Join(adminDir, "locked")); err == nil {
+			wt.Locked = true
+			wt.LockReason = strings.TrimSpace(string(reason))
+		}
+		// Like "git worktree prune", only unlocked worktrees whose directory is gone
+		wt.Prunable = !wt.Locked && !fileExists(dotGit)
+		linked = append(linked, wt)
+	}
+
+	sort.Slice(linked, func(i, j int) bool { return linked[i].Path < linked[j].Path })
+	return append(worktrees, linked...)
+}
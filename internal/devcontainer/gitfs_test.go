@@ -0,0 +1,307 @@
+package devcontainer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	fixtureSHA1 = "1111111111111111111111111111111111111111"
+	fixtureSHA2 = "2222222222222222222222222222222222222222"
+	fixtureSHA3 = "3333333333333333333333333333333333333333"
+)
+
+// writeFixture creates files (relative path -> content) under root
+func writeFixture(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(root, filepath.FromSlash(name)), content)
+	}
+}
+
+// newFixtureRepo builds a repository with three linked worktrees without running git:
+//
+//	base/repo             main worktree on "main" (loose ref)
+//	base/repo-feature     linked worktree on "feature/x" (packed ref), locked
+//	base/repo-detached    linked worktree with a detached HEAD, relative gitdir paths
+//	base/repo-gone        linked worktree whose directory was deleted (prunable)
+func newFixtureRepo(t *testing.T) (base string) {
+	t.Helper()
+	base = t.TempDir()
+	repo := filepath.Join(base, "repo")
+	admin := filepath.Join(repo, ".git", "worktrees")
+
+	writeFixture(t, base, map[string]string{
+		"repo/.git/HEAD":                     "ref: refs/heads/main\n",
+		"repo/.git/refs/heads/main":          fixtureSHA1 + "\n",
+		"repo/.git/refs/remotes/origin/HEAD": "ref: refs/remotes/origin/main\n",
+		"repo/.git/packed-refs": "# pack-refs with: peeled fully-peeled sorted\n" +
+			fixtureSHA2 + " refs/heads/feature/x\n" +
+			fixtureSHA3 + " refs/tags/v1\n" +
+			"^" + fixtureSHA1 + "\n",
+
+		"repo/.git/worktrees/feature/HEAD":      "ref: refs/heads/feature/x\n",
+		"repo/.git/worktrees/feature/commondir": "../..\n",
+		"repo/.git/worktrees/feature/gitdir":    filepath.Join(base, "repo-feature", ".git") + "\n",
+		"repo/.git/worktrees/feature/locked":    "agent running\n",
+		"repo-feature/.git":                     "gitdir: " + filepath.Join(admin, "feature") + "\n",
+
+		"repo/.git/worktrees/detached/HEAD":      fixtureSHA3 + "\n",
+		"repo/.git/worktrees/detached/commondir": "../..\n",
+		"repo/.git/worktrees/detached/gitdir":    "../../../../repo-detached/.git\n",
+		"repo-detached/.git":                     "gitdir: ../repo/.git/worktrees/detached\n",
+
+		"repo/.git/worktrees/gone/HEAD":   "ref: refs/heads/main\n",
+		"repo/.git/worktrees/gone/gitdir": filepath.Join(base, "repo-gone", ".git") + "\n",
+	})
+	return base
+}
+
+func TestIsGitWorktree_Fixture(t *testing.T) {
+	base := newFixtureRepo(t)
+	repo := filepath.Join(base, "repo")
+
+	tests := []struct {
+		name     string
+		path     string
+		expected WorktreeInfo
+	}{
+		{
+			name: "main worktree",
+			path: repo,
+			expected: WorktreeInfo{
+				Path:      repo,
+				Branch:    "main",
+				MainRepo:  repo,
+				GitDir:    filepath.Join(repo, ".git"),
+				CommonDir: filepath.Join(repo, ".git"),
+				IsMain:    true,
+				Head:      fixtureSHA1,
+			},
+		},
+		{
+			name: "linked worktree on packed branch",
+			path: filepath.Join(base, "repo-feature"),
+			expected: WorktreeInfo{
+				Path:      filepath.Join(base, "repo-feature"),
+				Branch:    "feature/x",
+				MainRepo:  repo,
+				GitDir:    filepath.Join(repo, ".git", "worktrees", "feature"),
+				CommonDir: filepath.Join(repo, ".git"),
+				Head:      fixtureSHA2,
+			},
+		},
+		{
+			name: "detached worktree with relative gitdir",
+			path: filepath.Join(base, "repo-detached"),
+			expected: WorktreeInfo{
+				Path:      filepath.Join(base, "repo-detached"),
+				MainRepo:  repo,
+				GitDir:    filepath.Join(repo, ".git", "worktrees", "detached"),
+				CommonDir: filepath.Join(repo, ".git"),
+				Detached:  true,
+				Head:      fixtureSHA3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsGitWorktree(tt.path)
+			if result == nil {
+				t.Fatal("IsGitWorktree() returned nil")
+			}
+			if !reflect.DeepEqual(*result, tt.expected) {
+				t.Errorf("IsGitWorktree() = %+v, want %+v", *result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestListWorktrees_Fixture(t *testing.T) {
+	base := newFixtureRepo(t)
+	repo := filepath.Join(base, "repo")
+
+	// Listing from a linked worktree resolves the same repository
+	worktrees, err := ListWorktrees(filepath.Join(base, "repo-feature"))
+	if err != nil {
+		t.Fatalf("ListWorktrees() error: %v", err)
+	}
+
+	type summary struct {
+		Path, Branch, MainRepo string
+		IsMain, Detached       bool
+		Locked, Prunable       bool
+		LockReason             string
+	}
+	var got []summary
+	for _, wt := range worktrees {
+		got = append(got, summary{wt.Path, wt.Branch, wt.MainRepo, wt.IsMain, wt.Detached, wt.Locked, wt.Prunable, wt.LockReason})
+	}
+	want := []summary{
+		{repo, "main", repo, true, false, false, false, ""},
+		{filepath.Join(base, "repo-detached"), "", repo, false, true, false, false, ""},
+		{filepath.Join(base, "repo-feature"), "feature/x", repo, false, false, true, false, "agent running"},
+		{filepath.Join(base, "repo-gone"), "main", repo, false, false, false, true, ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListWorktrees() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if _, err := ListWorktrees(t.TempDir()); err == nil {
+		t.Error("ListWorktrees() on a non-repository should fail")
+	}
+}
+
+func TestGitDirs_Refs(t *testing.T) {
+	base := newFixtureRepo(t)
+	g, ok := openGitDirs(filepath.Join(base, "repo"))
+	if !ok {
+		t.Fatal("openGitDirs() failed on fixture repo")
+	}
+
+	tests := []struct {
+		ref     string
+		wantSHA string
+		wantOK  bool
+	}{
+		{"refs/heads/main", fixtureSHA1, true},
+		{"refs/heads/feature/x", fixtureSHA2, true},
+		{"refs/tags/v1", fixtureSHA3, true},
+		{"refs/remotes/origin/HEAD", "", false}, // Points at a missing ref
+		{"refs/heads/missing", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			sha, ok := g.resolveRef(tt.ref)
+			if sha != tt.wantSHA || ok != tt.wantOK {
+				t.Errorf("resolveRef(%q) = %q, %v; want %q, %v", tt.ref, sha, ok, tt.wantSHA, tt.wantOK)
+			}
+		})
+	}
+
+	if got := DefaultBranch(filepath.Join(base, "repo")); got != "main" {
+		t.Errorf("DefaultBranch() = %q, want %q (from origin/HEAD)", got, "main")
+	}
+}
+
+// TestListWorktrees_MatchesGit cross-checks the on-disk reader against a real repository
+func TestListWorktrees_MatchesGit(t *testing.T) {
+	repo := initTestRepo(t)
+	feature := addTestWorktree(t, repo, "feature")
+	runTestGit(t, repo, "pack-refs", "--all")
+	runTestGit(t, repo, "worktree", "lock", "--reason", "busy", feature.Path)
+
+	worktrees, err := ListWorktrees(repo)
+	if err != nil {
+		t.Fatalf("ListWorktrees() error: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("ListWorktrees() returned %d worktrees, want 2", len(worktrees))
+	}
+
+	porcelain := runTestGit(t, repo, "worktree", "list", "--porcelain")
+	for _, wt := range worktrees {
+		if !strings.Contains(porcelain, "worktree "+wt.Path) {
+			t.Errorf("path %q not reported by git:\n%s", wt.Path, porcelain)
+		}
+		if !strings.Contains(porcelain, "branch refs/heads/"+wt.Branch) {
+			t.Errorf("branch %q not reported by git:\n%s", wt.Branch, porcelain)
+		}
+	}
+	if !worktrees[1].Locked || worktrees[1].LockReason != "busy" {
+		t.Errorf("linked worktree Locked=%v LockReason=%q, want locked with reason", worktrees[1].Locked, worktrees[1].LockReason)
+	}
+	if got := getGitBranch(feature.Path); got != "feature" {
+		t.Errorf("getGitBranch() = %q, want %q", got, "feature")
+	}
+}
+
+func TestIsGitWorktree_BareFixture(t *testing.T) {
+	base := t.TempDir()
+	bare := filepath.Join(base, "app", ".bare")
+	writeFixture(t, base, map[string]string{
+		"app/.git":                  "gitdir: ./.bare\n",
+		"app/.bare/HEAD":            "ref: refs/heads/main\n",
+		"app/.bare/config":          "[core]\n\trepositoryformatversion = 0\n\tbare = true\n",
+		"app/.bare/refs/heads/main": fixtureSHA1 + "\n",
+
+		"app/.bare/worktrees/main/HEAD":      "ref: refs/heads/main\n",
+		"app/.bare/worktrees/main/commondir": "../..\n",
+		"app/.bare/worktrees/main/gitdir":    filepath.Join(base, "app", "main", ".git") + "\n",
+		"app/main/.git":                      "gitdir: " + filepath.Join(bare, "worktrees", "main") + "\n",
+	})
+
+	// The project directory and the bare repository have no working tree
+	for _, path := range []string{filepath.Join(base, "app"), bare} {
+		if wt := IsGitWorktree(path); wt != nil {
+			t.Errorf("IsGitWorktree(%q) = %+v, want nil", path, *wt)
+		}
+	}
+
+	wt := IsGitWorktree(filepath.Join(base, "app", "main"))
+	if wt == nil {
+		t.Fatal("IsGitWorktree() returned nil for a worktree of a bare repository")
+	}
+	if wt.IsMain || !wt.Bare || wt.MainRepo != bare || wt.CommonDir != bare || wt.Branch != "main" {
+		t.Errorf("IsGitWorktree() = %+v, want linked worktree of bare repo %s", *wt, bare)
+	}
+	if got := wt.RepoName(); got != "app" {
+		t.Errorf("RepoName() = %q, want %q", got, "app")
+	}
+	if got, want := worktreePathFor(*wt, "feature/x"), filepath.Join(base, "app", "feature-x"); got != want {
+		t.Errorf("worktreePathFor() = %q, want %q", got, want)
+	}
+
+	// Listing from the project directory finds the linked worktree only
+	worktrees, err := ListWorktrees(filepath.Join(base, "app"))
+	if err != nil {
+		t.Fatalf("ListWorktrees() error: %v", err)
+	}
+	if len(worktrees) != 1 || worktrees[0].Path != filepath.Join(base, "app", "main") {
+		t.Errorf("ListWorktrees() = %+v, want only the linked worktree", worktrees)
+	}
+}
+
+// TestCreateWorktree_BareRepo exercises a "git clone --bare" layout with real git
+func TestCreateWorktree_BareRepo(t *testing.T) {
+	origin := initTestRepo(t)
+	bare := filepath.Join(filepath.Dir(origin), "app.git")
+	runTestGit(t, filepath.Dir(origin), "clone", "-q", "--bare", origin, bare)
+	mainPath := filepath.Join(filepath.Dir(origin), "app-main")
+	runTestGit(t, bare, "worktree", "add", "-q", mainPath, "main")
+
+	wtPath, _, err := CreateWorktree(mainPath, "feature", false, WorktreeConfig{})
+	if err != nil {
+		t.Fatalf("CreateWorktree() error: %v", err)
+	}
+	if want := filepath.Join(filepath.Dir(origin), "app-feature"); wtPath != want {
+		t.Errorf("CreateWorktree() path = %q, want %q", wtPath, want)
+	}
+	runTestGit(t, mainPath, "checkout", "-q", "--detach")
+
+	worktrees, err := ListWorktrees(bare)
+	if err != nil {
+		t.Fatalf("ListWorktrees() error: %v", err)
+	}
+	porcelain := runTestGit(t, bare, "worktree", "list", "--porcelain")
+	if len(worktrees) != 2 {
+		t.Fatalf("ListWorktrees() = %+v, want 2 linked worktrees\n%s", worktrees, porcelain)
+	}
+	for _, wt := range worktrees {
+		if wt.IsMain || !wt.Bare || wt.MainRepo != bare || wt.RepoName() != "app" {
+			t.Errorf("worktree %+v should be a linked worktree of %s", wt, bare)
+		}
+		if !strings.Contains(porcelain, "worktree "+wt.Path) {
+			t.Errorf("path %q not reported by git:\n%s", wt.Path, porcelain)
+		}
+	}
+	if !worktrees[1].Detached || worktrees[1].Branch != "" || !strings.Contains(porcelain, "HEAD "+worktrees[1].Head) {
+		t.Errorf("worktree %+v should be detached at the commit git reports:\n%s", worktrees[1], porcelain)
+	}
+	if got := DefaultBranch(bare); got != "main" {
+		t.Errorf("DefaultBranch() = %q, want %q", got, "main")
+	}
+}
@@ -0,0 +1,170 @@
+package devcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// baseBranchConfigKey is the git config key (under branch.<name>) recording
+// the branch a worktree branch was created from
+const baseBranchConfigKey = "claudeQuickBase"
+
+// IntegrateMode selects how a worktree branch is brought up to date with its base
+type IntegrateMode int
+
+const (
+	IntegrateRebase IntegrateMode = iota // Rebase the branch onto its base
+	IntegrateMerge                       // Merge the base into the branch
+)
+
+// String returns the git command name for the mode
+func (m IntegrateMode) String() string {
+	if m == IntegrateMerge {
+		return "merge"
+	}
+	return "rebase"
+}
+
+// IntegrateResult describes the outcome of integrating a worktree branch
+type IntegrateResult struct {
+	Branch        string   // Branch that was integrated
+	Base          string   // Base branch it was integrated with
+	Conflicts     []string // Conflicted paths; non-empty means the rebase/merge is stopped
+	FastForwarded bool     // True if the base branch was fast-forwarded to the branch
+}
+
+// recordBaseBranch remembers which branch a new worktree branch was created from
+func recordBaseBranch(mainRepo, branch, base string) {
+	if base == "" || base == "HEAD" || base == branch {
+		return
+	}
+	_ = gitRun(mainRepo, "failed to record base branch", "config", "branch."+branch+"."+baseBranchConfigKey, base)
+}
+
+// BaseBranch returns the branch a worktree should be integrated into: the branch it
+// was created from if recorded, otherwise the repository's default branch
+func BaseBranch(wt WorktreeInfo) string {
+	if branch := IntegratingBranch(wt); branch != "" {
+		if base, err := gitOutput(wt.MainRepo, "config", "--get", "branch."+branch+"."+baseBranchConfigKey); err == nil && base != "" {
+			return base
+		}
+	}
+	return DefaultBranch(wt.MainRepo)
+}
+
+// IntegratingBranch returns the worktree's branch. A stopped rebase detaches HEAD,
+// so the branch being rebased is read from the rebase state instead.
+func IntegratingBranch(wt WorktreeInfo) string {
+	if wt.Branch != "" {
+		return wt.Branch
+	}
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		if data, err := os.ReadFile(filepath.Join(wt.GitDir, dir, "head-name")); err == nil {
+			return strings.TrimPrefix(strings.TrimSpace(string(data)), "refs/heads/")
+		}
+	}
+	return ""
+}
+
+// IntegrateWorktree rebases the worktree's branch onto its base (or merges the base
+// into it) on the host. If fastForwardBase is set and the integration succeeds, the
+// base branch is fast-forwarded to the branch, updating the main worktree if it has
+// the base checked out. Conflicts are reported in the result, leaving the rebase or
+// merge in progress so they can be resolved or aborted.
+func IntegrateWorktree(wt WorktreeInfo, mode IntegrateMode, fastForwardBase bool) (IntegrateResult, error) {
+	result := IntegrateResult{Branch: IntegratingBranch(wt), Base: BaseBranch(wt)}
+	if wt.IsMain {
+		return result, fmt.Errorf("cannot integrate the main worktree")
+	}
+	// Checked before the detached HEAD, which a stopped rebase leaves behind
+	if IntegrationInProgress(wt) {
+		return result, fmt.Errorf("a rebase or merge is already in progress in %s", wt.Path)
+	}
+	if wt.Detached {
+		return result, fmt.Errorf("worktree has a detached HEAD, check out a branch to integrate")
+	}
+	if result.Base == "" {
+		return result, fmt.Errorf("could not determine base branch for %s", wt.Branch)
+	}
+
+	// Rebase and merge refuse to run over uncommitted changes to tracked files
+	if changes, err := gitOutput(wt.Path, "status", "--porcelain", "--untracked-files=no"); err != nil {
+		return result, fmt.Errorf("failed to read worktree status: %w", err)
+	} else if changes != "" {
+		return result, fmt.Errorf("worktree has uncommitted changes, commit or stash them first")
+	}
+
+	var err error
+	if mode == IntegrateMerge {
+		err = gitRun(wt.Path, "merge failed", "merge", "--no-edit", result.Base)
+	} else {
+		err = gitRun(wt.Path, "rebase failed", "rebase", result.Base)
+	}
+	if err != nil {
+		if conflicts := ConflictedFiles(wt); len(conflicts) > 0 {
+			result.Conflicts = conflicts
+			return result, nil
+		}
+		return result, err
+	}
+
+	if fastForwardBase {
+		if err := fastForwardBranch(wt, result.Base); err != nil {
+			return result, fmt.Errorf("%s succeeded but %w", mode, err)
+		}
+		result.FastForwarded = true
+	}
+	return result, nil
+}
+
+// fastForwardBranch moves base forward to the worktree's branch without creating a
+// merge commit. If another worktree (such as the main one) has base checked out, its
+// working tree is updated too.
+func fastForwardBranch(wt WorktreeInfo, base string) error {
+	errPrefix := "fast-forward of " + base + " failed"
+	if worktrees, err := ListWorktrees(wt.MainRepo); err == nil {
+		for _, other := range worktrees {
+			if other.Branch == base && !other.Prunable {
+				return gitRun(other.Path, errPrefix, "merge", "--ff-only", wt.Branch)
+			}
+		}
+	}
+	// Not checked out anywhere: update the ref, refusing non-fast-forwards
+	return gitRun(wt.MainRepo, errPrefix, "fetch", ".", wt.Branch+":"+base)
+}
+
+// ConflictedFiles returns the paths with unresolved conflicts in a worktree
+func ConflictedFiles(wt WorktreeInfo) []string {
+	output, err := gitOutput(wt.Path, "diff", "--name-only", "--diff-filter=U")
+	if err != nil || output == "" {
+		return nil
+	}
+	return strings.Split(output, "\n")
+}
+
+// IntegrationInProgress returns true if a rebase or merge is stopped in the worktree
+func IntegrationInProgress(wt WorktreeInfo) bool {
+	return rebaseInProgress(wt) || fileExists(filepath.Join(wt.GitDir, "MERGE_HEAD"))
+}
+
+// rebaseInProgress returns true if a rebase is stopped in the worktree
+func rebaseInProgress(wt WorktreeInfo) bool {
+	return fileExists(filepath.Join(wt.GitDir, "rebase-merge")) ||
+		fileExists(filepath.Join(wt.GitDir, "rebase-apply"))
+}
+
+// AbortIntegration aborts a stopped rebase or merge in the worktree
+func AbortIntegration(wt WorktreeInfo) error {
+	if rebaseInProgress(wt) {
+		return gitRun(wt.Path, "failed to abort rebase", "rebase", "--abort")
+	}
+	return gitRun(wt.Path, "failed to abort merge", "merge", "--abort")
+}
+
+// fileExists returns true if path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
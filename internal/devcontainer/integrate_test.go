@@ -0,0 +1,143 @@
+package devcontainer
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// commitTestFile writes a file in dir and commits it
+func commitTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	writeTestFile(t, filepath.Join(dir, name), content)
+	runTestGit(t, dir, "add", name)
+	runTestGit(t, dir, "commit", "-q", "-m", "update "+name)
+}
+
+func TestBaseBranch(t *testing.T) {
+	repo := initTestRepo(t)
+	runTestGit(t, repo, "checkout", "-q", "-b", "develop")
+
+	// Created through CreateWorktree: base recorded from the main worktree's branch
+	wtPath, _, err := CreateWorktree(repo, "feature", false, WorktreeConfig{})
+	if err != nil {
+		t.Fatalf("CreateWorktree() error: %v", err)
+	}
+	if got := BaseBranch(*IsGitWorktree(wtPath)); got != "develop" {
+		t.Errorf("BaseBranch() = %q, want recorded %q", got, "develop")
+	}
+
+	// Created outside claude-quick: falls back to the default branch
+	other := addTestWorktree(t, repo, "other")
+	if got := BaseBranch(other); got != "main" {
+		t.Errorf("BaseBranch() = %q, want default %q", got, "main")
+	}
+}
+
+func TestIntegrateWorktree_RebaseAndFastForward(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	commitTestFile(t, wt.Path, "feature.txt", "feature\n")
+	commitTestFile(t, repo, "main.txt", "main\n")
+
+	result, err := IntegrateWorktree(wt, IntegrateRebase, true)
+	if err != nil {
+		t.Fatalf("IntegrateWorktree() error: %v", err)
+	}
+	if len(result.Conflicts) != 0 || !result.FastForwarded || result.Base != "main" {
+		t.Errorf("result = %+v, want clean fast-forwarded integration into main", result)
+	}
+
+	branchHead := runTestGit(t, wt.Path, "rev-parse", "HEAD")
+	if mainHead := runTestGit(t, repo, "rev-parse", "HEAD"); mainHead != branchHead {
+		t.Errorf("main HEAD = %s, want fast-forwarded to %s", mainHead, branchHead)
+	}
+	if _, err := gitOutput(repo, "cat-file", "-e", "HEAD:feature.txt"); err != nil {
+		t.Error("main worktree should contain the feature commit")
+	}
+	if merges := runTestGit(t, repo, "rev-list", "--merges", "HEAD"); merges != "" {
+		t.Error("rebase should not create merge commits")
+	}
+}
+
+func TestIntegrateWorktree_MergeWithoutFastForward(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	commitTestFile(t, wt.Path, "feature.txt", "feature\n")
+	commitTestFile(t, repo, "main.txt", "main\n")
+	mainBefore := runTestGit(t, repo, "rev-parse", "HEAD")
+
+	result, err := IntegrateWorktree(wt, IntegrateMerge, false)
+	if err != nil {
+		t.Fatalf("IntegrateWorktree() error: %v", err)
+	}
+	if result.FastForwarded {
+		t.Error("base should not be fast-forwarded")
+	}
+	if mainAfter := runTestGit(t, repo, "rev-parse", "HEAD"); mainAfter != mainBefore {
+		t.Error("main should be unchanged")
+	}
+	if _, err := gitOutput(wt.Path, "cat-file", "-e", "HEAD:main.txt"); err != nil {
+		t.Error("branch should contain the merged base commit")
+	}
+}
+
+func TestIntegrateWorktree_ConflictsAndAbort(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	commitTestFile(t, wt.Path, "README.md", "feature version\n")
+	commitTestFile(t, repo, "README.md", "main version\n")
+	branchBefore := runTestGit(t, wt.Path, "rev-parse", "HEAD")
+
+	result, err := IntegrateWorktree(wt, IntegrateRebase, true)
+	if err != nil {
+		t.Fatalf("IntegrateWorktree() error: %v", err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != "README.md" {
+		t.Fatalf("Conflicts = %v, want [README.md]", result.Conflicts)
+	}
+	if result.FastForwarded {
+		t.Error("base must not be fast-forwarded when conflicts stop the rebase")
+	}
+	if !IntegrationInProgress(wt) {
+		t.Fatal("rebase should be left in progress")
+	}
+
+	// The stopped rebase detaches HEAD, but is still found along with its branch
+	stopped := *IsGitWorktree(wt.Path)
+	if !stopped.Detached || !IntegrationInProgress(stopped) {
+		t.Fatalf("stopped rebase: Detached = %v, in progress = %v; want both", stopped.Detached, IntegrationInProgress(stopped))
+	}
+	if got := IntegratingBranch(stopped); got != "feature" {
+		t.Errorf("IntegratingBranch() = %q, want %q", got, "feature")
+	}
+	runTestGit(t, repo, "config", "branch.feature."+baseBranchConfigKey, "develop")
+	if got := BaseBranch(stopped); got != "develop" {
+		t.Errorf("BaseBranch() during rebase = %q, want recorded %q", got, "develop")
+	}
+
+	// A second attempt refuses to start over the stopped rebase
+	if _, err := IntegrateWorktree(stopped, IntegrateRebase, false); err == nil || !strings.Contains(err.Error(), "in progress") {
+		t.Errorf("IntegrateWorktree() error = %v, want a rebase in progress error", err)
+	}
+
+	if err := AbortIntegration(wt); err != nil {
+		t.Fatalf("AbortIntegration() error: %v", err)
+	}
+	if IntegrationInProgress(wt) {
+		t.Error("rebase should be aborted")
+	}
+	if after := runTestGit(t, wt.Path, "rev-parse", "HEAD"); after != branchBefore {
+		t.Error("abort should restore the branch")
+	}
+}
+
+func TestIntegrateWorktree_DirtyWorktree(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	writeTestFile(t, filepath.Join(wt.Path, "README.md"), "uncommitted\n")
+
+	if _, err := IntegrateWorktree(wt, IntegrateRebase, false); err == nil {
+		t.Error("IntegrateWorktree() should refuse a worktree with uncommitted changes")
+	}
+}
@@ -0,0 +1,121 @@
+package devcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+	"github.com/christophergyman/claude-quick/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+// SavedSessions is the tmux session layout of an instance, recorded on the host
+// before its container stops so the sessions can be recreated after it starts
+type SavedSessions struct {
+	Path     string              `yaml:"path"`
+	SavedAt  time.Time           `yaml:"saved_at"`
+	Sessions []tmux.SavedSession `yaml:"sessions"`
+}
+
+// savedSessionsFile returns the file an instance's layout is saved to in dir,
+// named after the workspace folder and a hash of its full path
+func savedSessionsFile(dir, projectPath string) string {
+	return filepath.Join(dir, util.PathKey(projectPath)+".yaml")
+}
+
+// SaveTmuxSessions records the layout of every tmux session in the container to dir
+// and returns how many sessions were saved. An earlier recording is kept if no
+// sessions are running.
+func SaveTmuxSessions(projectPath, dir string) (int, error) {
+	output, err := execInContainer(projectPath, "sh", "-c", tmux.SnapshotScript(constants.TmuxTemplateOption))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record tmux sessions: %w", err)
+	}
+	sessions := tmux.ParseSnapshot(string(output))
+	if len(sessions) == 0 {
+		return 0, nil
+	}
+	saved := SavedSessions{Path: projectPath, SavedAt: time.Now(), Sessions: sessions}
+	if err := writeSavedSessions(dir, saved); err != nil {
+		return 0, err
+	}
+	return len(sessions), nil
+}
+
+// writeSavedSessions writes a recorded layout to dir, creating dir if needed
+func writeSavedSessions(dir string, saved SavedSessions) error {
+	data, err := yaml.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to encode tmux sessions: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create session state directory: %w", err)
+	}
+	if err := os.WriteFile(savedSessionsFile(dir, saved.Path), data, 0o600); err != nil {
+		return fmt.Errorf("failed to save tmux sessions: %w", err)
+	}
+	return nil
+}
+
+// LoadSavedSessions returns the layout recorded for an instance in dir, or nil if
+// there is none
+func LoadSavedSessions(projectPath, dir string) (*SavedSessions, error) {
+	data, err := os.ReadFile(savedSessionsFile(dir, projectPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved tmux sessions: %w", err)
+	}
+	var saved SavedSessions
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse saved tmux sessions: %w", err)
+	}
+	if saved.Path != projectPath || len(saved.Sessions) == 0 {
+		return nil, nil
+	}
+	return &saved, nil
+}
+
+// DiscardSavedSessions removes the layout recorded for an instance in dir
+func DiscardSavedSessions(projectPath, dir string) error {
+	if err := os.Remove(savedSessionsFile(dir, projectPath)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove saved tmux sessions: %w", err)
+	}
+	return nil
+}
+
+// moveSavedSessions carries the layout recorded for an instance in dir over to its
+// new path
+func moveSavedSessions(oldPath, newPath, dir string) error {
+	saved, err := LoadSavedSessions(oldPath, dir)
+	if err != nil || saved == nil {
+		return err
+	}
+	saved.Path = newPath
+	if err := writeSavedSessions(dir, *saved); err != nil {
+		return err
+	}
+	return DiscardSavedSessions(oldPath, dir)
+}
+
+// RestoreTmuxSessions recreates saved sessions in the container with their windows,
+// panes, working directories and commands. Panes that ran the agent run
+// launchCommand. Returns one error per session that could not be recreated.
+func RestoreTmuxSessions(projectPath string, sessions []tmux.SavedSession, launchCommand string, style tmux.Style) []error {
+	var errs []error
+	for _, session := range sessions {
+		layout := session.Layout()
+		if err := layout.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", session.Name, err))
+			continue
+		}
+		if err := CreateTmuxSession(projectPath, session.Name, launchCommand, &layout, style); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", session.Name, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,52 @@
+package devcontainer
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+func TestSavedSessions_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	saved := SavedSessions{
+		Path:    "/src/app",
+		SavedAt: time.Date(2026, 3, 4, 15, 6, 7, 0, time.UTC),
+		Sessions: []tmux.SavedSession{{
+			Name:     "main",
+			Template: "agent",
+			Windows: []tmux.WindowTemplate{{
+				Layout: "tiled",
+				Panes:  []tmux.PaneTemplate{{Dir: "/src/app", Launch: true, Focus: true}, {Dir: "/src/app/web", Command: "npm run dev"}},
+			}},
+		}},
+	}
+
+	if got, err := LoadSavedSessions("/src/app", dir); err != nil || got != nil {
+		t.Fatalf("LoadSavedSessions() before saving = %v, %v, want nil", got, err)
+	}
+	if err := writeSavedSessions(dir, saved); err != nil {
+		t.Fatalf("writeSavedSessions() error = %v", err)
+	}
+	got, err := LoadSavedSessions("/src/app", dir)
+	if err != nil || got == nil || !reflect.DeepEqual(*got, saved) {
+		t.Fatalf("LoadSavedSessions() = %+v, %v, want %+v", got, err, saved)
+	}
+
+	// Another project with the same folder name has its own file
+	if other, err := LoadSavedSessions("/other/app", dir); err != nil || other != nil {
+		t.Errorf("LoadSavedSessions() for another path = %v, %v, want nil", other, err)
+	}
+
+	if err := DiscardSavedSessions("/src/app", dir); err != nil {
+		t.Fatalf("DiscardSavedSessions() error = %v", err)
+	}
+	if _, err := os.Stat(savedSessionsFile(dir, "/src/app")); !os.IsNotExist(err) {
+		t.Error("DiscardSavedSessions() should remove the file")
+	}
+	if err := DiscardSavedSessions("/src/app", dir); err != nil {
+		t.Errorf("DiscardSavedSessions() without a file = %v, want nil", err)
+	}
+}
@@ -1,14 +1,18 @@
 package devcontainer
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/christophergyman/claude-quick/internal/auth"
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 // ListTmuxSessions lists tmux sessions inside the container
@@ -32,129 +36,208 @@ func ListTmuxSessions(projectPath string) ([]string, error) {
 	return sessions, nil
 }
 
-// CreateTmuxSession creates a new tmux session in the container.
-// If launchCommand is non-empty, it will be sent to the session after creation.
-func CreateTmuxSession(projectPath, sessionName, launchCommand string) error {
-	// Read credentials BEFORE creating session so they're available to the initial shell
-	creds := readCredentialFile(projectPath)
-
-	// Build tmux command with -e flags to inject env vars at session creation time
-	// This ensures the initial shell gets the credentials (setenv only affects new windows)
-	args := []string{"new-session", "-d", "-s", sessionName}
-	for name, value := range creds {
-		args = append(args, "-e", fmt.Sprintf("%s=%s", name, value))
+// CreateTmuxSession creates a new tmux session in the container and styles it.
+// If tmpl is non-nil, its windows and panes are built and panes marked "launch"
+// run launchCommand; otherwise a single window is created and launchCommand,
+// if non-empty, is sent to it. Everything is set up in a single exec.
+func CreateTmuxSession(projectPath, sessionName, launchCommand string, tmpl *tmux.Template, style tmux.Style) error {
+	setup := tmux.SessionSetup{
+		Name: sessionName,
+		// Credentials are passed at session creation so the initial shell gets them
+		Env:           auth.ReadCredentialFile(projectPath),
+		Template:      tmpl,
+		LaunchCommand: launchCommand,
+		Style:         style,
+	}
+	if tmpl != nil && tmpl.Name != "" {
+		// Remember the template so a restart rebuilds the same layout
+		setup.Options = map[string]string{constants.TmuxTemplateOption: tmpl.Name}
 	}
+	return execInContainerWithStderr(projectPath, "failed to create tmux session", setup.ExecArgs()...)
+}
 
-	if err := execInContainerWithStderr(projectPath, "failed to create tmux session",
-		append([]string{"tmux"}, args...)...); err != nil {
-		return err
+// TmuxSessionTemplate returns the name of the template a session was created from,
+// or an empty string if it was created without one
+func TmuxSessionTemplate(projectPath, sessionName string) string {
+	output, err := execInContainer(projectPath, "tmux", "show-options", "-t", sessionName, "-qv", constants.TmuxTemplateOption)
+	if err != nil {
+		return ""
 	}
+	return strings.TrimSpace(string(output))
+}
 
-	// Apply Anthropic-themed styling to the session
-	applyTmuxStyling(projectPath, sessionName)
+// CaptureSessionPanes captures the active pane of every tmux session in the container,
+// keeping the last lines of each, keyed by session name. Returns an empty map if no
+// sessions exist.
+func CaptureSessionPanes(projectPath string, lines int) (map[string]tmux.Pane, error) {
+	output, err := execInContainer(projectPath, "sh", "-c", tmux.PaneProbeScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture tmux panes: %w", err)
+	}
+	return tmux.ParsePaneProbe(string(output), lines), nil
+}
 
-	// Also set via setenv for any new windows/panes created later
-	injectTmuxSessionEnv(projectPath, sessionName)
+// HasTmux checks if tmux is available in the container
+func HasTmux(projectPath string) bool {
+	_, err := execInContainer(projectPath, "which", "tmux")
+	return err == nil
+}
 
-	// Run launch command if specified
-	if launchCommand != "" {
-		execInContainer(projectPath, "tmux", "send-keys", "-t", sessionName, launchCommand, "Enter")
+// KillTmuxSession kills a tmux session in the container. If archiveDir is not empty
+// the session's scrollback is exported there first, and the session is left running
+// if that fails.
+func KillTmuxSession(projectPath, sessionName, archiveDir string) error {
+	if archiveDir != "" {
+		if _, err := ExportScrollback(projectPath, sessionName, archiveDir); err != nil {
+			return fmt.Errorf("session not killed: %w", err)
+		}
 	}
-
-	return nil
+	return execInContainerWithStderr(projectPath, "failed to kill tmux session",
+		"tmux", "kill-session", "-t", sessionName)
 }
 
-// injectTmuxSessionEnv reads credentials from the auth file and sets them as tmux session env vars.
-// Uses "tmux setenv" which propagates to all new windows/panes in the session.
-func injectTmuxSessionEnv(projectPath, sessionName string) {
-	creds := readCredentialFile(projectPath)
-	for name, value := range creds {
-		// tmux setenv -t session NAME value
-		execInContainer(projectPath, "tmux", "setenv", "-t", sessionName, name, value)
+// ExportScrollback writes the full scrollback of every pane of a session (of every
+// session if sessionName is empty) to timestamped files in dir on the host, one file
+// per session, and returns their paths. Nothing is written if no sessions exist.
+func ExportScrollback(projectPath, sessionName, dir string) ([]string, error) {
+	output, err := execInContainer(projectPath, "sh", "-c", tmux.ScrollbackScript, "sh", sessionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture tmux scrollback: %w", err)
 	}
+	return writeTranscripts(dir, tmux.ParseScrollback(string(output)), time.Now())
 }
 
-// readCredentialFile parses the .claude-quick-auth file and returns env var name/value pairs.
-func readCredentialFile(projectPath string) map[string]string {
-	result := make(map[string]string)
+// writeTranscripts writes each session's transcript to dir as
+// <timestamp>-<session>.log, creating dir if needed. Earlier transcripts are never
+// overwritten: a file taken within the same second gets a numeric suffix.
+func writeTranscripts(dir string, transcripts map[string]string, now time.Time) ([]string, error) {
+	if len(transcripts) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create transcript directory: %w", err)
+	}
 
-	filePath := filepath.Join(projectPath, auth.CredFileName)
-	file, err := os.Open(filePath)
-	if err != nil {
-		return result // File doesn't exist or can't be read
+	sessions := make([]string, 0, len(transcripts))
+	for session := range transcripts {
+		sessions = append(sessions, session)
 	}
-	defer file.Close()
+	sort.Strings(sessions)
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	var paths []string
+	for _, session := range sessions {
+		path, err := writeTranscript(dir, session, now, transcripts[session])
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
 
-		// Skip comments and empty lines
-		if line == "" || strings.HasPrefix(line, "#") {
+// writeTranscript writes a session's transcript to a new file in dir and returns
+// its path, counting up the suffix until the name is free
+func writeTranscript(dir, sessionName string, now time.Time, transcript string) (string, error) {
+	for n := 1; ; n++ {
+		path := filepath.Join(dir, transcriptFileName(sessionName, now, n))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if os.IsExist(err) {
 			continue
 		}
-
-		// Parse "export NAME='value'" format
-		if strings.HasPrefix(line, "export ") {
-			line = strings.TrimPrefix(line, "export ")
-			if idx := strings.Index(line, "="); idx > 0 {
-				name := line[:idx]
-				value := line[idx+1:]
-
-				// Remove only the outermost quotes (not all leading/trailing quote chars)
-				// strings.Trim would remove ALL matching chars, corrupting escaped values
-				if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
-					value = value[1 : len(value)-1]
-				}
-
-				// Handle escaped single quotes: 'val'"'"'ue' -> val'ue
-				// The pattern '\"'\"' is shell escaping for a literal single quote
-				value = strings.ReplaceAll(value, "'\"'\"'", "'")
-
-				result[name] = value
-			}
+		if err != nil {
+			return "", fmt.Errorf("failed to write transcript: %w", err)
+		}
+		_, err = f.WriteString(transcript)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to write transcript: %w", err)
 		}
+		return path, nil
 	}
-
-	return result
 }
 
-// HasTmux checks if tmux is available in the container
-func HasTmux(projectPath string) bool {
-	_, err := execInContainer(projectPath, "which", "tmux")
-	return err == nil
+// transcriptFileName returns the file name of the nth session transcript taken at
+// the given time (numbered from 1, which has no suffix), with characters that are
+// unsafe in file names replaced
+func transcriptFileName(sessionName string, now time.Time, n int) string {
+	safe := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, sessionName)
+	name := now.Format("20060102-150405") + "-" + safe
+	if n > 1 {
+		name += "-" + strconv.Itoa(n)
+	}
+	return name + ".log"
 }
 
-// KillTmuxSession kills a tmux session in the container
-func KillTmuxSession(projectPath, sessionName string) error {
-	return execInContainerWithStderr(projectPath, "failed to kill tmux session",
-		"tmux", "kill-session", "-t", sessionName)
+// ListTmuxWindows lists the windows of all tmux sessions inside the container,
+// one tmux.WindowListFormat line per window
+func ListTmuxWindows(projectPath string) ([]string, error) {
+	output, err := execInContainer(projectPath, "tmux", "list-windows", "-a", "-F", tmux.WindowListFormat)
+	if err != nil {
+		// Exit code 1 means no server, so no windows
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+
+	var windows []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			windows = append(windows, line)
+		}
+	}
+	return windows, nil
 }
 
-// applyTmuxStyling applies Anthropic-themed styling to a tmux session.
-// Uses orange (#D97706) as the primary color with git branch display.
-func applyTmuxStyling(projectPath, sessionName string) {
-	// Status bar colors - Anthropic orange
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "status-style", "bg=#D97706,fg=#FFFFFF")
+// RenameTmuxSession renames a tmux session in the container
+func RenameTmuxSession(projectPath, sessionName, newName string) error {
+	return execInContainerWithStderr(projectPath, "failed to rename tmux session",
+		"tmux", "rename-session", "-t", sessionName, newName)
+}
 
-	// Status left: session name with padding
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "status-left", " #S ")
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "status-left-style", "bg=#B45309,fg=#FFFFFF,bold")
+// RenameTmuxWindow renames a window of a tmux session in the container
+func RenameTmuxWindow(projectPath, sessionName string, index int, newName string) error {
+	return execInContainerWithStderr(projectPath, "failed to rename tmux window",
+		"tmux", "rename-window", "-t", tmux.WindowTarget(sessionName, index), newName)
+}
 
-	// Status right: git branch + window/pane info
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "status-right",
-		" #(git -C #{pane_current_path} rev-parse --abbrev-ref HEAD 2>/dev/null || echo 'no-branch') │ #I:#P ")
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "status-right-style", "bg=#B45309,fg=#FFFFFF")
+// NewTmuxWindow adds a window to a tmux session in the container, named by tmux
+// after its command if name is empty
+func NewTmuxWindow(projectPath, sessionName, name string) error {
+	args := []string{"tmux", "new-window", "-d", "-t", sessionName + ":"}
+	if name != "" {
+		args = append(args, "-n", name)
+	}
+	return execInContainerWithStderr(projectPath, "failed to create tmux window", args...)
+}
 
-	// Current window styling (stands out in window list)
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "window-status-current-style", "bg=#FFFFFF,fg=#D97706,bold")
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "window-status-current-format", " #I:#W ")
+// KillTmuxWindow kills a window of a tmux session in the container.
+// Killing the last window of a session ends the session.
+func KillTmuxWindow(projectPath, sessionName string, index int) error {
+	return execInContainerWithStderr(projectPath, "failed to kill tmux window",
+		"tmux", "kill-window", "-t", tmux.WindowTarget(sessionName, index))
+}
 
-	// Other windows styling
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "window-status-style", "fg=#FFF7ED")
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "window-status-format", " #I:#W ")
+// MoveTmuxWindow moves a window to the first free index of another session in the container
+func MoveTmuxWindow(projectPath, sessionName string, index int, destSession string) error {
+	return execInContainerWithStderr(projectPath, "failed to move tmux window",
+		"tmux", "move-window", "-d", "-s", tmux.WindowTarget(sessionName, index), "-t", destSession+":")
+}
 
-	// Pane border colors for consistency
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "pane-border-style", "fg=#D97706")
-	execInContainer(projectPath, "tmux", "set-option", "-t", sessionName, "pane-active-border-style", "fg=#F97316")
+// SendKeys types text into the active pane of each session and presses Enter,
+// using a single exec for all of them
+func SendKeys(projectPath string, sessionNames []string, text string) error {
+	var cmds [][]string
+	for _, name := range sessionNames {
+		cmds = append(cmds, tmux.SendTextCommands(name, text)...)
+	}
+	return execInContainerWithStderr(projectPath, "failed to send keys",
+		append([]string{"tmux"}, tmux.JoinCommands(cmds)...)...)
 }
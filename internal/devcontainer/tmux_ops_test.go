@@ -0,0 +1,69 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTranscriptFileName(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 6, 7, 0, time.UTC)
+	tests := []struct {
+		session string
+		n       int
+		want    string
+	}{
+		{"main", 1, "20260304-150607-main.log"},
+		{"main", 2, "20260304-150607-main-2.log"},
+		{"feature/login", 1, "20260304-150607-feature_login.log"},
+		{"a:b\\c", 1, "20260304-150607-a_b_c.log"},
+	}
+	for _, tt := range tests {
+		if got := transcriptFileName(tt.session, now, tt.n); got != tt.want {
+			t.Errorf("transcriptFileName(%q, %d) = %q, want %q", tt.session, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTranscripts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "app")
+	now := time.Date(2026, 3, 4, 15, 6, 7, 0, time.UTC)
+
+	paths, err := writeTranscripts(dir, map[string]string{"main": "=== 0.0: claude ===\nhi\n", "logs": "x\n"}, now)
+	if err != nil {
+		t.Fatalf("writeTranscripts() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(dir, "20260304-150607-logs.log"),
+		filepath.Join(dir, "20260304-150607-main.log"),
+	}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	data, err := os.ReadFile(want[1])
+	if err != nil || string(data) != "=== 0.0: claude ===\nhi\n" {
+		t.Errorf("main transcript = %q, %v", data, err)
+	}
+	if info, err := os.Stat(want[1]); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("transcript mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A second export within the same second keeps the first
+	again, err := writeTranscripts(dir, map[string]string{"main": "later\n"}, now)
+	if wantAgain := filepath.Join(dir, "20260304-150607-main-2.log"); err != nil || len(again) != 1 || again[0] != wantAgain {
+		t.Fatalf("second export = %v, %v, want %s", again, err, wantAgain)
+	}
+	if data, _ := os.ReadFile(want[1]); string(data) != "=== 0.0: claude ===\nhi\n" {
+		t.Errorf("first transcript was overwritten with %q", data)
+	}
+
+	// No sessions writes nothing, not even the directory
+	empty := filepath.Join(t.TempDir(), "none")
+	if paths, err := writeTranscripts(empty, nil, now); err != nil || paths != nil {
+		t.Errorf("writeTranscripts(nil) = %v, %v", paths, err)
+	}
+	if _, err := os.Stat(empty); !os.IsNotExist(err) {
+		t.Error("writeTranscripts(nil) should not create the directory")
+	}
+}
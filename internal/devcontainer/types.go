@@ -2,7 +2,14 @@
 // git worktrees, and tmux sessions within devcontainers.
 package devcontainer
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
 
 // Project represents a devcontainer project
 type Project struct {
@@ -12,21 +19,31 @@ type Project struct {
 
 // WorktreeInfo represents a git worktree
 type WorktreeInfo struct {
-	Path     string // Worktree directory path
-	Branch   string // Current branch name
-	MainRepo string // Path to main repository
-	GitDir   string // Path to worktree gitdir (.git/worktrees/<name>)
-	IsMain   bool   // True if this is the main worktree
+	Path      string // Worktree directory path
+	Branch    string // Current branch name (empty when HEAD is detached)
+	MainRepo  string // Path to main repository (the bare git directory for bare repos)
+	GitDir    string // Path to worktree gitdir (.git/worktrees/<name>)
+	CommonDir string // Shared git directory holding refs and objects (.git or the bare repo)
+	IsMain    bool   // True if this is the main worktree
+	Bare      bool   // True if the repository is bare and has no main worktree
+
+	Detached bool   // True if HEAD points at a commit rather than a branch
+	Head     string // Commit SHA checked out (empty if unknown)
+
+	Locked     bool   // True if the worktree is locked against prune/move/remove
+	LockReason string // Optional reason given when locking
+	Prunable   bool   // True if the worktree directory is gone and git would prune it
 }
 
 // newMainWorktreeInfo creates a WorktreeInfo for the main worktree of a repo
 func newMainWorktreeInfo(path, branch string) WorktreeInfo {
 	return WorktreeInfo{
-		Path:     path,
-		Branch:   branch,
-		MainRepo: path,
-		GitDir:   filepath.Join(path, ".git"),
-		IsMain:   true,
+		Path:      path,
+		Branch:    branch,
+		MainRepo:  path,
+		GitDir:    filepath.Join(path, ".git"),
+		CommonDir: filepath.Join(path, ".git"),
+		IsMain:    true,
 	}
 }
 
@@ -34,12 +51,66 @@ func newMainWorktreeInfo(path, branch string) WorktreeInfo {
 func newBranchWorktreeInfo(path, branch, mainRepo string) WorktreeInfo {
 	worktreeName := filepath.Base(path)
 	return WorktreeInfo{
-		Path:     path,
-		Branch:   branch,
-		MainRepo: mainRepo,
-		GitDir:   filepath.Join(mainRepo, ".git", "worktrees", worktreeName),
-		IsMain:   false,
+		Path:      path,
+		Branch:    branch,
+		MainRepo:  mainRepo,
+		GitDir:    filepath.Join(mainRepo, ".git", "worktrees", worktreeName),
+		CommonDir: filepath.Join(mainRepo, ".git"),
+		IsMain:    false,
+	}
+}
+
+// BranchLabel returns the branch name, or the short commit SHA when detached
+func (w WorktreeInfo) BranchLabel() string {
+	if !w.Detached {
+		return w.Branch
+	}
+	if len(w.Head) > constants.SHATruncateLength {
+		return w.Head[:constants.SHATruncateLength]
+	}
+	return w.Head
+}
+
+// RepoName returns the repository name used for display and per-project config.
+// Bare repositories drop the ".git" suffix ("app.git" -> "app"); a bare directory
+// hidden inside a project directory ("app/.bare") is named after that directory.
+func (w WorktreeInfo) RepoName() string {
+	name := filepath.Base(w.MainRepo)
+	if !w.Bare {
+		return name
+	}
+	if name = strings.TrimSuffix(name, ".git"); name == "" || strings.HasPrefix(name, ".") {
+		return filepath.Base(filepath.Dir(w.MainRepo))
 	}
+	return name
+}
+
+// WorktreeStatus describes work that would be lost by deleting a worktree
+type WorktreeStatus struct {
+	Branch          string   // Branch checked out in the worktree
+	BaseBranch      string   // Branch the merge check was made against (empty if unknown)
+	ChangedFiles    []string // Uncommitted paths (staged, unstaged and untracked)
+	UnpushedCommits int      // Commits not present on any remote
+	HasUpstream     bool     // True if the branch tracks a remote branch
+	Merged          bool     // True if the branch is fully merged into BaseBranch
+}
+
+// IsDirty returns true if the worktree has uncommitted changes
+func (s WorktreeStatus) IsDirty() bool {
+	return len(s.ChangedFiles) > 0
+}
+
+// IsSafeToDelete returns true if deleting the worktree and its branch loses no work
+func (s WorktreeStatus) IsSafeToDelete() bool {
+	return !s.IsDirty() && s.UnpushedCommits == 0 && s.Merged
+}
+
+// CleanupCandidate is a worktree whose work appears to be finished and can be removed
+type CleanupCandidate struct {
+	Instance     ContainerInstance // The worktree instance to remove
+	Reason       string            // Why the work is considered done (e.g. "merged into main")
+	LastActivity time.Time         // Time of the latest commit on the branch (zero if unknown)
+	AtRisk       string            // Work that removal would lose (e.g. "2 uncommitted file(s)"), empty if none
 }
 
 // ContainerStatus represents the runtime status of a devcontainer
@@ -65,12 +136,13 @@ type ContainerInstanceWithStatus struct {
 	Status       ContainerStatus
 	ContainerID  string
 	SessionCount int
+	AgentStates  map[string]tmux.AgentState // Agent state of each tmux session, by session name
 }
 
 // DisplayName returns the formatted name for UI display
 func (c ContainerInstance) DisplayName() string {
 	if c.Worktree != nil && !c.Worktree.IsMain {
-		return c.Name + " [" + c.Worktree.Branch + "]"
+		return c.Name + " [" + c.Worktree.BranchLabel() + "]"
 	}
 	return c.Name
 }
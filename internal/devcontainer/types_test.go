@@ -169,6 +169,18 @@ func TestContainerInstance_DisplayName(t *testing.T) {
 			},
 			expected: "project [bugfix/issue-42]",
 		},
+		{
+			name: "with detached worktree",
+			instance: ContainerInstance{
+				Project: Project{Name: "project", Path: "/workspace/project-review"},
+				Worktree: &WorktreeInfo{
+					Path:     "/workspace/project-review",
+					Detached: true,
+					Head:     "0123456789abcdef0123456789abcdef01234567",
+				},
+			},
+			expected: "project [0123456]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,6 +193,28 @@ func TestContainerInstance_DisplayName(t *testing.T) {
 	}
 }
 
+func TestWorktreeInfo_RepoName(t *testing.T) {
+	tests := []struct {
+		name     string
+		wt       WorktreeInfo
+		expected string
+	}{
+		{"main repo", WorktreeInfo{MainRepo: "/src/myapp"}, "myapp"},
+		{"bare with suffix", WorktreeInfo{MainRepo: "/src/myapp.git", Bare: true}, "myapp"},
+		{"bare without suffix", WorktreeInfo{MainRepo: "/src/myapp", Bare: true}, "myapp"},
+		{"hidden bare dir", WorktreeInfo{MainRepo: "/src/myapp/.bare", Bare: true}, "myapp"},
+		{"bare .git dir", WorktreeInfo{MainRepo: "/src/myapp/.git", Bare: true}, "myapp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.wt.RepoName(); got != tt.expected {
+				t.Errorf("RepoName() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestContainerStatusConstants(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -240,3 +274,28 @@ func TestContainerInstanceWithStatus(t *testing.T) {
 		t.Errorf("Name = %q, want %q", instance.Name, "myapp")
 	}
 }
+
+func TestWorktreeStatus_IsSafeToDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    WorktreeStatus
+		wantDirty bool
+		wantSafe  bool
+	}{
+		{"clean and merged", WorktreeStatus{Merged: true}, false, true},
+		{"not merged", WorktreeStatus{Merged: false}, false, false},
+		{"uncommitted changes", WorktreeStatus{Merged: true, ChangedFiles: []string{"a.go"}}, true, false},
+		{"unpushed commits", WorktreeStatus{Merged: true, UnpushedCommits: 2}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.IsDirty(); got != tt.wantDirty {
+				t.Errorf("IsDirty() = %v, want %v", got, tt.wantDirty)
+			}
+			if got := tt.status.IsSafeToDelete(); got != tt.wantSafe {
+				t.Errorf("IsSafeToDelete() = %v, want %v", got, tt.wantSafe)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package devcontainer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+)
+
+// Copy modes for worktree bootstrap files
+const (
+	CopyModeCopy    = "copy"    // Copy files into the worktree (default)
+	CopyModeSymlink = "symlink" // Symlink files back to the main repo
+)
+
+// Submodule modes for new worktrees
+const (
+	SubmodulesInit      = "init"      // Initialize top-level submodules (default)
+	SubmodulesRecursive = "recursive" // Initialize submodules and their nested submodules
+	SubmodulesOff       = "off"       // Leave submodules uninitialized
+)
+
+// WorktreeConfig holds per-project settings applied when creating worktrees
+type WorktreeConfig struct {
+	// Copy lists glob patterns (relative to the main repo) of untracked files
+	// such as .env or local certificates to bring into new worktrees
+	Copy []string `yaml:"worktree_copy,omitempty"`
+	// CopyMode is "copy" (default) or "symlink"
+	CopyMode string `yaml:"worktree_copy_mode,omitempty"`
+	// PostCreate lists shell commands run on the host in the new worktree
+	// before its container is started
+	PostCreate []string `yaml:"worktree_post_create,omitempty"`
+	// ReservedBranches lists branch names or globs (e.g. "release/*") that
+	// cannot be used for worktrees. Defaults to main and master.
+	ReservedBranches []string `yaml:"reserved_branches,omitempty"`
+	// Submodules is "init" (default), "recursive" or "off"
+	Submodules string `yaml:"worktree_submodules,omitempty"`
+	// SparseCheckout lists directories (sparse-checkout cones) to check out in
+	// new worktrees. Empty checks out the whole repository.
+	SparseCheckout []string `yaml:"worktree_sparse_checkout,omitempty"`
+}
+
+// Merge returns c with any fields set in override taking precedence
+func (c WorktreeConfig) Merge(override WorktreeConfig) WorktreeConfig {
+	if len(override.Copy) > 0 {
+		c.Copy = override.Copy
+	}
+	if override.CopyMode != "" {
+		c.CopyMode = override.CopyMode
+	}
+	if len(override.PostCreate) > 0 {
+		c.PostCreate = override.PostCreate
+	}
+	if len(override.ReservedBranches) > 0 {
+		c.ReservedBranches = override.ReservedBranches
+	}
+	if override.Submodules != "" {
+		c.Submodules = override.Submodules
+	}
+	if len(override.SparseCheckout) > 0 {
+		c.SparseCheckout = override.SparseCheckout
+	}
+	return c
+}
+
+// ReservedBranchPatterns returns the configured reserved branches, or the defaults
+func (c WorktreeConfig) ReservedBranchPatterns() []string {
+	if len(c.ReservedBranches) > 0 {
+		return c.ReservedBranches
+	}
+	return constants.ReservedBranchNames
+}
+
+// Validate checks that the worktree configuration is valid
+func (c WorktreeConfig) Validate() error {
+	switch c.CopyMode {
+	case "", CopyModeCopy, CopyModeSymlink:
+	default:
+		return fmt.Errorf("invalid worktree_copy_mode %q (must be %q or %q)", c.CopyMode, CopyModeCopy, CopyModeSymlink)
+	}
+	for _, pattern := range c.Copy {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid worktree_copy pattern %q: %w", pattern, err)
+		}
+		if filepath.IsAbs(pattern) || strings.HasPrefix(filepath.Clean(pattern), "..") {
+			return fmt.Errorf("worktree_copy pattern %q must be relative to the repository", pattern)
+		}
+	}
+	for _, pattern := range c.ReservedBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid reserved_branches pattern %q: %w", pattern, err)
+		}
+	}
+	switch c.Submodules {
+	case "", SubmodulesInit, SubmodulesRecursive, SubmodulesOff:
+	default:
+		return fmt.Errorf("invalid worktree_submodules %q (must be %q, %q or %q)",
+			c.Submodules, SubmodulesInit, SubmodulesRecursive, SubmodulesOff)
+	}
+	for _, dir := range c.SparseCheckout {
+		clean := path.Clean(filepath.ToSlash(dir))
+		if dir == "" || clean == "." || path.IsAbs(clean) || strings.HasPrefix(clean, "..") {
+			return fmt.Errorf("worktree_sparse_checkout entry %q must be a directory inside the repository", dir)
+		}
+		if strings.ContainsAny(dir, "*?[") {
+			return fmt.Errorf("worktree_sparse_checkout entry %q must be a directory, not a pattern", dir)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package devcontainer
+
+import "testing"
+
+func TestWorktreeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     WorktreeConfig
+		wantErr bool
+	}{
+		{"empty", WorktreeConfig{}, false},
+		{"copy mode", WorktreeConfig{Copy: []string{".env*"}, CopyMode: CopyModeCopy}, false},
+		{"symlink mode", WorktreeConfig{CopyMode: CopyModeSymlink}, false},
+		{"unknown mode", WorktreeConfig{CopyMode: "hardlink"}, true},
+		{"bad pattern", WorktreeConfig{Copy: []string{"[.env"}}, true},
+		{"absolute pattern", WorktreeConfig{Copy: []string{"/etc/passwd"}}, true},
+		{"parent pattern", WorktreeConfig{Copy: []string{"../secrets"}}, true},
+		{"submodules recursive", WorktreeConfig{Submodules: SubmodulesRecursive}, false},
+		{"submodules off", WorktreeConfig{Submodules: SubmodulesOff}, false},
+		{"unknown submodules mode", WorktreeConfig{Submodules: "always"}, true},
+		{"sparse dirs", WorktreeConfig{SparseCheckout: []string{"packages/api", "tools/"}}, false},
+		{"sparse root", WorktreeConfig{SparseCheckout: []string{"."}}, true},
+		{"sparse parent", WorktreeConfig{SparseCheckout: []string{"../other"}}, true},
+		{"sparse absolute", WorktreeConfig{SparseCheckout: []string{"/packages"}}, true},
+		{"sparse pattern", WorktreeConfig{SparseCheckout: []string{"packages/*"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorktreeConfig_Merge(t *testing.T) {
+	global := WorktreeConfig{Copy: []string{".env"}, CopyMode: CopyModeSymlink, PostCreate: []string{"make"}}
+
+	merged := global.Merge(WorktreeConfig{Copy: []string{".env.local"}})
+	if len(merged.Copy) != 1 || merged.Copy[0] != ".env.local" {
+		t.Errorf("Copy = %v, want override", merged.Copy)
+	}
+	if merged.CopyMode != CopyModeSymlink || len(merged.PostCreate) != 1 {
+		t.Errorf("unset fields should keep global values, got %+v", merged)
+	}
+}
@@ -0,0 +1,107 @@
+package devcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+	"github.com/christophergyman/claude-quick/internal/util"
+)
+
+// LockWorktree locks a worktree so git will not prune, move or remove it
+func LockWorktree(wt WorktreeInfo, reason string) error {
+	if wt.IsMain {
+		return fmt.Errorf("cannot lock the main worktree")
+	}
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, wt.Path)
+	return gitRun(wt.MainRepo, "failed to lock worktree", args...)
+}
+
+// UnlockWorktree removes the lock from a worktree
+func UnlockWorktree(wt WorktreeInfo) error {
+	return gitRun(wt.MainRepo, "failed to unlock worktree", "worktree", "unlock", wt.Path)
+}
+
+// RenameWorktree renames a worktree's branch and moves its directory to match.
+// Containers are identified by their workspace path, so the old container is
+// stopped and removed; wasRunning reports whether it should be started again
+// at the new path. Untracked files move with the directory. What is kept about
+// the worktree on the host, keyed by its path, is carried over to the new path:
+// its credential file, its saved session layout in sessionDir and its transcript
+// directory under transcriptRoot.
+func RenameWorktree(wt WorktreeInfo, newBranch string, cfg WorktreeConfig, sessionDir, transcriptRoot string) (newPath string, wasRunning bool, err error) {
+	if wt.IsMain {
+		return "", false, fmt.Errorf("cannot rename the main worktree")
+	}
+	if wt.Locked {
+		return "", false, fmt.Errorf("worktree is locked, unlock it before renaming")
+	}
+	if wt.Detached {
+		return "", false, fmt.Errorf("worktree has a detached HEAD, there is no branch to rename")
+	}
+	if newBranch == wt.Branch {
+		return "", false, fmt.Errorf("branch is already named %s", newBranch)
+	}
+	if err := ValidateBranchName(newBranch, cfg.ReservedBranchPatterns()); err != nil {
+		return "", false, err
+	}
+	if localBranchExists(wt.MainRepo, newBranch) {
+		return "", false, fmt.Errorf("branch already exists: %s", newBranch)
+	}
+
+	newPath = worktreePathFor(wt, newBranch)
+	if _, err := os.Stat(newPath); err == nil {
+		return "", false, fmt.Errorf("worktree directory already exists: %s", newPath)
+	}
+
+	// The container's bind mount pins the old path, stop it before moving
+	status, containerID := GetContainerStatus(wt.Path)
+	wasRunning = status == StatusRunning
+	if wasRunning {
+		if err := Stop(wt.Path); err != nil {
+			return "", false, err
+		}
+	}
+
+	if err := gitRun(wt.MainRepo, "failed to rename branch", "branch", "-m", wt.Branch, newBranch); err != nil {
+		return "", wasRunning, err
+	}
+	if err := gitRun(wt.MainRepo, "failed to move worktree", "worktree", "move", wt.Path, newPath); err != nil {
+		// Restore the branch name so the worktree is left as it was
+		_ = gitRun(wt.MainRepo, "failed to restore branch name", "branch", "-m", newBranch, wt.Branch)
+		return "", wasRunning, err
+	}
+	if err := auth.MoveCredentialFile(wt.Path, newPath); err != nil {
+		return newPath, wasRunning, err
+	}
+	if err := moveSavedSessions(wt.Path, newPath, sessionDir); err != nil {
+		return newPath, wasRunning, err
+	}
+	if err := moveTranscriptDir(wt.Path, newPath, transcriptRoot); err != nil {
+		return newPath, wasRunning, err
+	}
+
+	// The old container is labelled with the old path and would be orphaned
+	if containerID == "" {
+		return newPath, wasRunning, nil
+	}
+	if err := RemoveContainer(wt.Path); err != nil {
+		return newPath, wasRunning, fmt.Errorf("worktree moved but old container was not removed: %w", err)
+	}
+	return newPath, wasRunning, nil
+}
+
+// moveTranscriptDir moves a worktree's transcript directory under root to match
+// its new path
+func moveTranscriptDir(oldPath, newPath, root string) error {
+	err := os.Rename(filepath.Join(root, util.PathKey(oldPath)), filepath.Join(root, util.PathKey(newPath)))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("worktree moved but its transcripts were not: %w", err)
+	}
+	return nil
+}
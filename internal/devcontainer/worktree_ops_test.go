@@ -0,0 +1,146 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/christophergyman/claude-quick/internal/tmux"
+	"github.com/christophergyman/claude-quick/internal/util"
+)
+
+// findTestWorktree returns the listed worktree at path, failing the test if missing
+func findTestWorktree(t *testing.T, repo, path string) WorktreeInfo {
+	t.Helper()
+	worktrees, err := ListWorktrees(repo)
+	if err != nil {
+		t.Fatalf("ListWorktrees() error: %v", err)
+	}
+	for _, wt := range worktrees {
+		if wt.Path == path {
+			return wt
+		}
+	}
+	t.Fatalf("worktree %s not listed", path)
+	return WorktreeInfo{}
+}
+
+func TestLockUnlockWorktree(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+
+	if err := LockWorktree(wt, "agent running"); err != nil {
+		t.Fatalf("LockWorktree() error: %v", err)
+	}
+	locked := findTestWorktree(t, repo, wt.Path)
+	if !locked.Locked || locked.LockReason != "agent running" {
+		t.Errorf("after lock: Locked=%v LockReason=%q, want true %q", locked.Locked, locked.LockReason, "agent running")
+	}
+
+	if err := UnlockWorktree(locked); err != nil {
+		t.Fatalf("UnlockWorktree() error: %v", err)
+	}
+	if findTestWorktree(t, repo, wt.Path).Locked {
+		t.Error("worktree should be unlocked")
+	}
+
+	main := newMainWorktreeInfo(repo, "main")
+	if err := LockWorktree(main, ""); err == nil {
+		t.Error("LockWorktree() on main worktree should fail")
+	}
+}
+
+func TestRenameWorktree(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	fakeDocker(t)
+	writeTestFile(t, filepath.Join(wt.Path, "notes.txt"), "untracked\n")
+	writeTestCredentials(t, wt.Path)
+
+	newPath, wasRunning, err := RenameWorktree(wt, "feature/renamed", WorktreeConfig{}, t.TempDir(), t.TempDir())
+	if err != nil {
+		t.Fatalf("RenameWorktree() error: %v", err)
+	}
+	if wasRunning {
+		t.Error("wasRunning should be false without a container")
+	}
+	if want := filepath.Join(filepath.Dir(repo), "repo-feature-renamed"); newPath != want {
+		t.Errorf("newPath = %q, want %q", newPath, want)
+	}
+
+	if _, err := os.Stat(wt.Path); !os.IsNotExist(err) {
+		t.Error("old worktree directory should be gone")
+	}
+	if _, err := os.Stat(filepath.Join(newPath, "notes.txt")); err != nil {
+		t.Errorf("untracked files should move with the worktree: %v", err)
+	}
+	if !hasCredentials(newPath) || hasCredentials(wt.Path) {
+		t.Error("credentials should move to the renamed worktree's path")
+	}
+	if moved := findTestWorktree(t, repo, newPath); moved.Branch != "feature/renamed" {
+		t.Errorf("moved worktree branch = %q, want %q", moved.Branch, "feature/renamed")
+	}
+}
+
+// TestRenameWorktree_MovesHostState checks that a saved session layout and the
+// transcripts kept on the host follow the worktree to its new path
+func TestRenameWorktree_MovesHostState(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	fakeDocker(t)
+	sessionDir, transcriptRoot := t.TempDir(), t.TempDir()
+	saved := SavedSessions{Path: wt.Path, Sessions: []tmux.SavedSession{{
+		Name:    "agent",
+		Windows: []tmux.WindowTemplate{{Panes: []tmux.PaneTemplate{{Launch: true}}}},
+	}}}
+	if err := writeSavedSessions(sessionDir, saved); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(transcriptRoot, util.PathKey(wt.Path), "agent.log"), "scrollback\n")
+
+	newPath, _, err := RenameWorktree(wt, "renamed", WorktreeConfig{}, sessionDir, transcriptRoot)
+	if err != nil {
+		t.Fatalf("RenameWorktree() error: %v", err)
+	}
+
+	restored, err := LoadSavedSessions(newPath, sessionDir)
+	if err != nil || restored == nil || restored.Sessions[0].Name != "agent" {
+		t.Errorf("LoadSavedSessions(newPath) = %+v, %v, want the saved session", restored, err)
+	}
+	if old, _ := LoadSavedSessions(wt.Path, sessionDir); old != nil {
+		t.Error("the layout should no longer be saved for the old path")
+	}
+	if _, err := os.Stat(filepath.Join(transcriptRoot, util.PathKey(newPath), "agent.log")); err != nil {
+		t.Errorf("transcripts should move with the worktree: %v", err)
+	}
+}
+
+func TestRenameWorktree_Rejected(t *testing.T) {
+	repo := initTestRepo(t)
+	wt := addTestWorktree(t, repo, "feature")
+	other := addTestWorktree(t, repo, "other")
+
+	tests := []struct {
+		name   string
+		wt     WorktreeInfo
+		branch string
+	}{
+		{"main worktree", newMainWorktreeInfo(repo, "main"), "renamed"},
+		{"same name", wt, "feature"},
+		{"existing branch", wt, other.Branch},
+		{"invalid name", wt, "bad name"},
+		{"reserved name", wt, "master"},
+		{"locked", WorktreeInfo{Path: wt.Path, Branch: wt.Branch, MainRepo: repo, Locked: true}, "renamed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := RenameWorktree(tt.wt, tt.branch, WorktreeConfig{}, t.TempDir(), t.TempDir()); err == nil {
+				t.Error("RenameWorktree() should fail")
+			}
+			if _, err := os.Stat(wt.Path); err != nil {
+				t.Errorf("worktree should be left in place: %v", err)
+			}
+		})
+	}
+}
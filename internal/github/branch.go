@@ -3,6 +3,7 @@ package github
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -26,6 +27,24 @@ func GenerateBranchName(issue *Issue, prefix string) string {
 	return branchName
 }
 
+// ParseIssueNumber extracts the issue number from a branch created by GenerateBranchName.
+// Returns false if the branch does not start with prefix followed by a number.
+func ParseIssueNumber(branchName, prefix string) (int, bool) {
+	if prefix == "" || !strings.HasPrefix(branchName, prefix) {
+		return 0, false
+	}
+	rest := strings.TrimPrefix(branchName, prefix)
+	end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end == -1 {
+		end = len(rest)
+	}
+	number, err := strconv.Atoi(rest[:end])
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
 // slugifyTitle converts a title to a URL-safe slug.
 func slugifyTitle(title string) string {
 	// Convert to lowercase
@@ -0,0 +1,40 @@
+package github
+
+import "testing"
+
+func TestParseIssueNumber(t *testing.T) {
+	tests := []struct {
+		name       string
+		branchName string
+		prefix     string
+		wantNumber int
+		wantOK     bool
+	}{
+		{"generated branch", "issue-42-fix-login-bug", "issue-", 42, true},
+		{"number only", "issue-7", "issue-", 7, true},
+		{"custom prefix", "gh/123-title", "gh/", 123, true},
+		{"missing prefix", "feature-42", "issue-", 0, false},
+		{"no number", "issue-fix-login", "issue-", 0, false},
+		{"empty prefix", "42-fix", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, ok := ParseIssueNumber(tt.branchName, tt.prefix)
+			if number != tt.wantNumber || ok != tt.wantOK {
+				t.Errorf("ParseIssueNumber(%q, %q) = (%d, %v), want (%d, %v)",
+					tt.branchName, tt.prefix, number, ok, tt.wantNumber, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateBranchName_RoundTrip(t *testing.T) {
+	issue := &Issue{Number: 321, Title: "Fix the login bug"}
+	branch := GenerateBranchName(issue, "issue-")
+
+	number, ok := ParseIssueNumber(branch, "issue-")
+	if !ok || number != issue.Number {
+		t.Errorf("ParseIssueNumber(%q) = (%d, %v), want (%d, true)", branch, number, ok, issue.Number)
+	}
+}
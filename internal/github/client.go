@@ -116,6 +116,75 @@ func FetchIssueBody(owner, repo string, number int) (string, error) {
 	return result.Body, nil
 }
 
+// FetchIssueState retrieves the current state of a single issue.
+func FetchIssueState(owner, repo string, number int) (IssueState, error) {
+	if err := CheckCLI(); err != nil {
+		return "", err
+	}
+
+	args := []string{
+		"issue", "view",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		fmt.Sprintf("%d", number),
+		"--json", "state",
+	}
+
+	cmd := exec.Command("gh", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("failed to fetch issue state: %s", string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("failed to fetch issue state: %w", err)
+	}
+
+	var result struct {
+		State IssueState `json:"state"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return "", fmt.Errorf("failed to parse issue state: %w", err)
+	}
+
+	return result.State, nil
+}
+
+// FetchMergedPRBranches returns the head branch names of merged pull requests.
+func FetchMergedPRBranches(owner, repo string, limit int) (map[string]bool, error) {
+	if err := CheckCLI(); err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"pr", "list",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--state", "merged",
+		"--limit", fmt.Sprintf("%d", limit),
+		"--json", "headRefName",
+	}
+
+	cmd := exec.Command("gh", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to fetch pull requests: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+
+	var prs []struct {
+		HeadRefName string `json:"headRefName"`
+	}
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse pull requests: %w", err)
+	}
+
+	branches := make(map[string]bool, len(prs))
+	for _, pr := range prs {
+		branches[pr.HeadRefName] = true
+	}
+	return branches, nil
+}
+
 // AddLabelToIssue adds a label to the specified issue.
 // If createIfMissing is true and the label doesn't exist, it will be created
 // with the specified color and description.
@@ -0,0 +1,109 @@
+// Package hosttmux opens container attaches in the host's own tmux, when
+// claude-quick itself runs inside tmux: each target gets a host window (or
+// pane) of its own, so agents can be tiled side by side instead of nesting
+// tmux-in-tmux in claude-quick's terminal.
+package hosttmux
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Modes for attaching from inside host tmux
+const (
+	ModeWindow = "window" // A host window per target (default when inside tmux)
+	ModePane   = "pane"   // A pane split off claude-quick's own
+	ModeOff    = "off"    // Attach in claude-quick's terminal, even inside tmux
+)
+
+// MarkerOption is the host tmux user option that records which target a
+// window or pane was opened for, so attaching again switches to it
+const MarkerOption = "@claude-quick"
+
+// ValidateMode checks that mode is a known host tmux mode ("" means window)
+func ValidateMode(mode string) error {
+	switch mode {
+	case "", ModeWindow, ModePane, ModeOff:
+		return nil
+	}
+	return fmt.Errorf("invalid host_tmux %q (must be %s, %s or %s)", mode, ModeWindow, ModePane, ModeOff)
+}
+
+// Active reports whether attaching should go through host tmux: claude-quick
+// runs inside tmux ($TMUX is set) and the mode isn't off
+func Active(mode string) bool {
+	return mode != ModeOff && os.Getenv("TMUX") != ""
+}
+
+// Open switches to the host window or pane marked with name, or opens one
+// running argv (according to mode) and marks it
+func Open(mode, name string, argv []string) error {
+	paneID, err := find(name)
+	if err != nil {
+		return err
+	}
+	if paneID != "" {
+		return focus(paneID)
+	}
+
+	var args []string
+	scope := "-w"
+	if mode == ModePane {
+		args = []string{"split-window", "-h", "-P", "-F", "#{pane_id}"}
+		if pane := os.Getenv("TMUX_PANE"); pane != "" {
+			args = append(args, "-t", pane)
+		}
+		scope = "-p"
+	} else {
+		args = []string{"new-window", "-P", "-F", "#{pane_id}", "-n", name}
+	}
+	output, err := run(append(args, argv...)...)
+	if err != nil {
+		return err
+	}
+	paneID = strings.TrimSpace(output)
+	_, err = run("set-option", scope, "-t", paneID, MarkerOption, name, ";",
+		"select-pane", "-t", paneID, "-T", name)
+	return err
+}
+
+// find returns the ID of a host pane marked with name (directly, or through
+// its window), or "" if there is none
+func find(name string) (string, error) {
+	output, err := run("list-panes", "-a", "-F", "#{pane_id}\t#{"+MarkerOption+"}")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		id, marker, ok := strings.Cut(line, "\t")
+		if ok && marker == name {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// focus makes a host pane the active one of its window, session and client
+func focus(paneID string) error {
+	if _, err := run("select-window", "-t", paneID, ";", "select-pane", "-t", paneID); err != nil {
+		return err
+	}
+	// Only possible with a client attached (it is, when claude-quick runs in tmux)
+	_, err := run("switch-client", "-t", paneID)
+	return err
+}
+
+// run runs a host tmux command and returns its output
+func run(args ...string) (string, error) {
+	cmd := exec.Command("tmux", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("host tmux %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
@@ -0,0 +1,104 @@
+package hosttmux
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateMode(t *testing.T) {
+	for _, mode := range []string{"", ModeWindow, ModePane, ModeOff} {
+		if err := ValidateMode(mode); err != nil {
+			t.Errorf("ValidateMode(%q) error = %v", mode, err)
+		}
+	}
+	if err := ValidateMode("split"); err == nil {
+		t.Error("ValidateMode(\"split\") error = nil, want error")
+	}
+}
+
+func TestActive(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if Active(ModeWindow) {
+		t.Error("Active() = true outside tmux")
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if !Active(ModeWindow) || !Active("") || !Active(ModePane) {
+		t.Error("Active() = false inside tmux")
+	}
+	if Active(ModeOff) {
+		t.Error("Active(off) = true")
+	}
+}
+
+// startTmux starts a tmux server on a private socket that bare "tmux" commands
+// (and so this package) talk to, and returns a helper to query it
+func startTmux(t *testing.T) func(args ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	t.Setenv("TMUX", socket+",0,0")
+	tmux := func(args ...string) string {
+		output, err := exec.Command("tmux", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("tmux %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	t.Cleanup(func() { _ = exec.Command("tmux", "kill-server").Run() })
+	// Start the server without the user's tmux.conf
+	tmux("-f", "/dev/null", "new-session", "-d", "-s", "host")
+	t.Setenv("TMUX_PANE", tmux("display-message", "-p", "-t", "host", "#{pane_id}"))
+	return tmux
+}
+
+func TestOpen_Window(t *testing.T) {
+	tmux := startTmux(t)
+
+	if err := Open(ModeWindow, "app/main", []string{"sleep", "300"}); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	windows := tmux("list-windows", "-t", "host", "-F", "#{window_name}|#{"+MarkerOption+"}")
+	if !strings.HasSuffix(windows, "\napp/main|app/main") {
+		t.Errorf("windows = %q, want a window marked app/main", windows)
+	}
+	id, err := find("app/main")
+	if err != nil || id == "" {
+		t.Fatalf("find() = %q, %v; want the new pane", id, err)
+	}
+	if cmd := tmux("display-message", "-p", "-t", id, "#{pane_current_command}"); cmd != "sleep" {
+		t.Errorf("pane command = %q, want sleep", cmd)
+	}
+
+	// Opening again switches to the window rather than opening another
+	// (switch-client fails without an attached client, which tests don't have)
+	_ = Open(ModeWindow, "app/main", []string{"sleep", "300"})
+	if n := len(strings.Split(tmux("list-windows", "-t", "host"), "\n")); n != 2 {
+		t.Errorf("windows after reopening = %d, want 2", n)
+	}
+	if active := tmux("display-message", "-p", "-t", "host", "#{pane_id}"); active != id {
+		t.Errorf("active pane = %q, want %q", active, id)
+	}
+}
+
+func TestOpen_Pane(t *testing.T) {
+	tmux := startTmux(t)
+
+	if err := Open(ModePane, "app/main (observe)", []string{"sleep", "300"}); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	panes := tmux("list-panes", "-t", "host", "-F", "#{pane_title}|#{"+MarkerOption+"}")
+	lines := strings.Split(panes, "\n")
+	if len(lines) != 2 || lines[1] != "app/main (observe)|app/main (observe)" {
+		t.Errorf("panes = %q, want a second pane marked app/main (observe)", panes)
+	}
+	if n := len(strings.Split(tmux("list-windows", "-t", "host"), "\n")); n != 1 {
+		t.Errorf("windows = %d, want 1", n)
+	}
+	if id, err := find("app/main"); err != nil || id != "" {
+		t.Errorf("find(other name) = %q, %v; want none", id, err)
+	}
+}
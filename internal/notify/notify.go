@@ -0,0 +1,123 @@
+// Package notify alerts the user when an agent needs attention, through the
+// terminal (bell, OSC 9/777 escapes) or the desktop (notify-send).
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+// Notification methods
+const (
+	MethodAuto    = "auto"        // notify-send if installed, otherwise OSC 9 and the bell
+	MethodBell    = "bell"        // Terminal bell
+	MethodOSC9    = "osc9"        // OSC 9 escape (iTerm2, WezTerm, Windows Terminal, ...)
+	MethodOSC777  = "osc777"      // OSC 777 escape (urxvt, foot, Ghostty, ...)
+	MethodDesktop = "notify-send" // Desktop notification via notify-send
+	MethodNone    = "none"        // Highlight dashboard rows only
+)
+
+// Agent transitions that can raise a notification
+const (
+	EventWaiting  = "waiting"  // Agent started waiting for input (permission or y/n prompt)
+	EventFinished = "finished" // Agent stopped working (back at its prompt, or exited)
+)
+
+// DefaultEvents returns the transitions that notify when none are configured
+func DefaultEvents() []string {
+	return []string{EventWaiting, EventFinished}
+}
+
+// ValidateMethod checks that method is a known notification method ("" means auto)
+func ValidateMethod(method string) error {
+	switch method {
+	case "", MethodAuto, MethodBell, MethodOSC9, MethodOSC777, MethodDesktop, MethodNone:
+		return nil
+	}
+	return fmt.Errorf("invalid notify_method %q (must be %s, %s, %s, %s, %s or %s)",
+		method, MethodAuto, MethodBell, MethodOSC9, MethodOSC777, MethodDesktop, MethodNone)
+}
+
+// ValidateEvents checks that every event is a known transition
+func ValidateEvents(events []string) error {
+	for _, event := range events {
+		if event != EventWaiting && event != EventFinished {
+			return fmt.Errorf("invalid notify event %q (must be %s or %s)", event, EventWaiting, EventFinished)
+		}
+	}
+	return nil
+}
+
+// Send delivers a notification with the given method. Terminal methods write
+// their escape sequences to term.
+func Send(term io.Writer, method, title, message string) error {
+	title, message = sanitize(title), sanitize(message)
+	switch method {
+	case MethodNone:
+		return nil
+	case MethodBell:
+		_, err := io.WriteString(term, "\a")
+		return err
+	case MethodOSC9:
+		_, err := io.WriteString(term, "\x1b]9;"+title+": "+message+"\a")
+		return err
+	case MethodOSC777:
+		_, err := io.WriteString(term, "\x1b]777;notify;"+strings.ReplaceAll(title, ";", ",")+";"+message+"\a")
+		return err
+	case MethodDesktop:
+		return exec.Command("notify-send", title, message).Run()
+	default:
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			return exec.Command("notify-send", title, message).Run()
+		}
+		// OSC 9, then the bell for terminals that ignore it
+		_, err := io.WriteString(term, "\x1b]9;"+title+": "+message+"\a\a")
+		return err
+	}
+}
+
+// sanitize drops control characters so text cannot end or inject escape sequences
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Tracker remembers the last agent state of each session and reports the
+// transitions worth notifying about. A session that stays in one state reports
+// nothing, so a waiting agent notifies once.
+type Tracker struct {
+	states map[string]tmux.AgentState
+}
+
+// NewTracker returns a Tracker that has seen no sessions
+func NewTracker() *Tracker {
+	return &Tracker{states: make(map[string]tmux.AgentState)}
+}
+
+// Observe records the state of the session identified by key and returns the
+// event its transition raises, or "" if none. The first observation of a session
+// only sets its baseline, and unknown states are ignored.
+func (t *Tracker) Observe(key string, state tmux.AgentState) string {
+	if state == tmux.AgentUnknown {
+		return ""
+	}
+	prev, seen := t.states[key]
+	t.states[key] = state
+	switch {
+	case !seen || prev == state:
+		return ""
+	case state == tmux.AgentWaiting:
+		return EventWaiting
+	case prev == tmux.AgentWorking && (state == tmux.AgentIdle || state == tmux.AgentExited):
+		return EventFinished
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+func TestTracker_Observe(t *testing.T) {
+	tracker := NewTracker()
+	steps := []struct {
+		key   string
+		state tmux.AgentState
+		want  string
+	}{
+		{"app/main", tmux.AgentWaiting, ""}, // Baseline only
+		{"app/main", tmux.AgentWaiting, ""}, // Still waiting, no repeat
+		{"app/main", tmux.AgentWorking, ""},
+		{"app/main", tmux.AgentUnknown, ""}, // Failed capture keeps the last state
+		{"app/main", tmux.AgentWaiting, EventWaiting},
+		{"app/main", tmux.AgentWaiting, ""},
+		{"app/main", tmux.AgentWorking, ""},
+		{"app/main", tmux.AgentIdle, EventFinished},
+		{"app/tests", tmux.AgentWorking, ""},
+		{"app/tests", tmux.AgentExited, EventFinished},
+		{"app/tests", tmux.AgentIdle, ""},
+	}
+
+	for i, step := range steps {
+		if got := tracker.Observe(step.key, step.state); got != step.want {
+			t.Errorf("step %d: Observe(%s, %v) = %q, want %q", i, step.key, step.state, got, step.want)
+		}
+	}
+}
+
+func TestSend_Terminal(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{MethodBell, "\a"},
+		{MethodOSC9, "\x1b]9;claude-quick: app: waiting\a"},
+		{MethodOSC777, "\x1b]777;notify;claude-quick;app: waiting\a"},
+		{MethodNone, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var buf bytes.Buffer
+			// The escape in the message must not reach the terminal
+			if err := Send(&buf, tt.method, "claude-quick", "app: \x1bwaiting"); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Send() wrote %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := ValidateMethod(""); err != nil {
+		t.Errorf("empty method should mean auto: %v", err)
+	}
+	if err := ValidateMethod("growl"); err == nil {
+		t.Error("unknown method should be rejected")
+	}
+	if err := ValidateEvents(DefaultEvents()); err != nil {
+		t.Errorf("default events should be valid: %v", err)
+	}
+	if err := ValidateEvents([]string{"idle"}); err == nil {
+		t.Error("unknown event should be rejected")
+	}
+}
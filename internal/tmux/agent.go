@@ -0,0 +1,103 @@
+package tmux
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AgentState is what the program in a session's active pane appears to be doing.
+// States are ordered by how much they need attention.
+type AgentState int
+
+const (
+	AgentUnknown AgentState = iota // Pane could not be inspected
+	AgentExited                    // Agent is gone: the pane is dead or back at a shell prompt
+	AgentIdle                      // Agent is running and waiting for its next prompt
+	AgentWorking                   // Agent is busy (thinking, running tools)
+	AgentWaiting                   // Agent is blocked on a question, e.g. a permission prompt
+)
+
+// String returns the badge label for the state
+func (s AgentState) String() string {
+	switch s {
+	case AgentExited:
+		return "exited"
+	case AgentIdle:
+		return "idle"
+	case AgentWorking:
+		return "working"
+	case AgentWaiting:
+		return "waiting"
+	default:
+		return "unknown"
+	}
+}
+
+// shells are pane commands that mean no program is running in the foreground
+var shells = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "fish": true, "dash": true,
+	"ash": true, "ksh": true, "tcsh": true, "csh": true, "login": true,
+}
+
+// waitingPattern matches questions that block an agent until answered:
+// Claude's permission prompts and their numbered choices, and y/n prompts
+var waitingPattern = regexp.MustCompile(`Do you want to|❯\s*1\.\s*Yes|\((?i:y/n)\)|\[(?i:y/n)\]`)
+
+// workingPattern matches the interrupt hint shown while an agent is busy
+var workingPattern = regexp.MustCompile(`(?i)(esc|ctrl\+c) to interrupt`)
+
+// Pane is a snapshot of the active pane of a session
+type Pane struct {
+	Session string
+	Command string   // Foreground process (tmux pane_current_command)
+	Dead    bool     // Program exited and the pane was kept (remain-on-exit)
+	Lines   []string // Last lines of the pane's visible output
+}
+
+// AgentState classifies the pane from its foreground process and visible output
+func (p Pane) AgentState() AgentState {
+	if p.Dead || shells[p.Command] {
+		return AgentExited
+	}
+	screen := strings.Join(p.Lines, "\n")
+	switch {
+	case waitingPattern.MatchString(screen):
+		return AgentWaiting
+	case workingPattern.MatchString(screen):
+		return AgentWorking
+	default:
+		return AgentIdle
+	}
+}
+
+// PaneProbeScript is a shell script that prints, for the active pane of every
+// session, a header line starting with a record separator (pane_dead, command and
+// session name, tab separated) followed by the pane's visible output. Run inside
+// the container, it inspects all sessions in a single exec; see ParsePaneProbe.
+const PaneProbeScript = "tmux list-panes -a -F '#{pane_id} #{window_active}#{pane_active} #{pane_dead}\t#{pane_current_command}\t#{session_name}' |\n" +
+	"while read -r id active pane; do\n" +
+	"\t[ \"$active\" = 11 ] || continue\n" +
+	"\tprintf '\\036%s\\n' \"$pane\"\n" +
+	"\ttmux capture-pane -p -t \"$id\"\n" +
+	"done\n"
+
+// ParsePaneProbe parses PaneProbeScript output into panes keyed by session name,
+// keeping the last n lines of each pane
+func ParsePaneProbe(output string, n int) map[string]Pane {
+	panes := make(map[string]Pane)
+	records := strings.Split(output, "\x1e")
+	for _, record := range records[1:] {
+		header, body, _ := strings.Cut(record, "\n")
+		fields := strings.SplitN(header, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		panes[fields[2]] = Pane{
+			Session: fields[2],
+			Command: fields[1],
+			Dead:    fields[0] == "1",
+			Lines:   LastLines(body, n),
+		}
+	}
+	return panes
+}
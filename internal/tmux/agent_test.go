@@ -0,0 +1,95 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPane_AgentState(t *testing.T) {
+	tests := []struct {
+		name string
+		pane Pane
+		want AgentState
+	}{
+		{"dead pane", Pane{Command: "claude", Dead: true}, AgentExited},
+		{"shell prompt returned", Pane{Command: "zsh", Lines: []string{"$ claude", "Bye!", "$"}}, AgentExited},
+		{"permission prompt", Pane{Command: "claude", Lines: []string{
+			"Do you want to make this edit to main.go?",
+			"❯ 1. Yes",
+			"  2. No, and tell Claude what to do differently (esc)",
+		}}, AgentWaiting},
+		{"y/n prompt", Pane{Command: "node", Lines: []string{"Overwrite config? (y/N)"}}, AgentWaiting},
+		{"thinking", Pane{Command: "claude", Lines: []string{"✻ Thinking… (12s · esc to interrupt)"}}, AgentWorking},
+		{"at input box", Pane{Command: "claude", Lines: []string{"> ", "? for shortcuts"}}, AgentIdle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pane.AgentState(); got != tt.want {
+				t.Errorf("AgentState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePaneProbe(t *testing.T) {
+	output := "\x1e0\tclaude\tagent one\nline 1\nesc to interrupt\n\n\x1e1\tclaude\tdone\n\x1ebroken header\n"
+	panes := ParsePaneProbe(output, 1)
+
+	if len(panes) != 2 {
+		t.Fatalf("got %d panes, want 2: %v", len(panes), panes)
+	}
+	agent := panes["agent one"]
+	if agent.Command != "claude" || agent.Dead || strings.Join(agent.Lines, "|") != "esc to interrupt" {
+		t.Errorf("agent pane = %+v", agent)
+	}
+	if done := panes["done"]; !done.Dead || len(done.Lines) != 0 {
+		t.Errorf("done pane = %+v", done)
+	}
+}
+
+// TestPaneProbeScript runs the probe against a private tmux server
+func TestPaneProbeScript(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	env := append(os.Environ(), "TMUX="+socket+",0,0") // Bare "tmux" commands use this server
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("tmux", append([]string{"-f", "/dev/null"}, args...)...)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("tmux %v failed: %v\n%s", args, err, output)
+		}
+	}
+	t.Cleanup(func() {
+		cmd := exec.Command("tmux", "kill-server")
+		cmd.Env = env
+		_ = cmd.Run()
+	})
+
+	run("new-session", "-d", "-s", "agent", "printf 'Do you want to proceed?\\n'; exec sleep 60")
+	run("new-session", "-d", "-s", "shell", "sh")
+
+	// Give the session commands a moment to start
+	var panes map[string]Pane
+	for range 20 {
+		cmd := exec.Command("sh", "-c", PaneProbeScript)
+		cmd.Env = env
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("probe failed: %v", err)
+		}
+		panes = ParsePaneProbe(string(output), 5)
+		if panes["agent"].AgentState() == AgentWaiting && panes["shell"].AgentState() == AgentExited {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Errorf("panes = %+v, want agent waiting and shell exited", panes)
+}
@@ -0,0 +1,50 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScrollbackScript is a shell script that prints the full scrollback of every pane
+// of the session named by its first argument, or of all sessions if it is empty.
+// Each pane starts with a header line beginning with a record separator (session
+// name, window.pane and command, tab separated); see ParseScrollback.
+const ScrollbackScript = "if [ -n \"$1\" ]; then set -- -s -t \"$1\"; else set -- -a; fi\n" +
+	"tmux list-panes \"$@\" -F '#{pane_id} #{session_name}\t#{window_index}.#{pane_index}\t#{pane_current_command}' |\n" +
+	"while read -r id pane; do\n" +
+	"\tprintf '\\036%s\\n' \"$pane\"\n" +
+	"\ttmux capture-pane -p -J -S - -t \"$id\"\n" +
+	"done\n"
+
+// ParseScrollback parses ScrollbackScript output into one transcript per session,
+// keyed by session name. Each pane's output is preceded by a
+// "=== window.pane: command ===" heading, with trailing blank lines dropped.
+func ParseScrollback(output string) map[string]string {
+	builders := make(map[string]*strings.Builder)
+	records := strings.Split(output, "\x1e")
+	for _, record := range records[1:] {
+		header, body, _ := strings.Cut(record, "\n")
+		fields := strings.SplitN(header, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		b, ok := builders[fields[0]]
+		if !ok {
+			b = &strings.Builder{}
+			builders[fields[0]] = b
+		} else {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "=== %s: %s ===\n", fields[1], fields[2])
+		if body = strings.TrimRight(body, " \t\n"); body != "" {
+			b.WriteString(body)
+			b.WriteString("\n")
+		}
+	}
+
+	transcripts := make(map[string]string, len(builders))
+	for session, b := range builders {
+		transcripts[session] = b.String()
+	}
+	return transcripts
+}
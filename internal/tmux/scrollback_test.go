@@ -0,0 +1,93 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseScrollback(t *testing.T) {
+	output := "\x1emain\t0.0\tclaude\nhello\nworld\n\n\n" +
+		"\x1emain\t0.1\tzsh\n\n" +
+		"\x1elogs\t1.0\ttail\nline\n" +
+		"\x1ebroken header\n"
+	transcripts := ParseScrollback(output)
+
+	if len(transcripts) != 2 {
+		t.Fatalf("got %d transcripts, want 2: %v", len(transcripts), transcripts)
+	}
+	wantMain := "=== 0.0: claude ===\nhello\nworld\n\n=== 0.1: zsh ===\n"
+	if got := transcripts["main"]; got != wantMain {
+		t.Errorf("main transcript = %q, want %q", got, wantMain)
+	}
+	if got := transcripts["logs"]; got != "=== 1.0: tail ===\nline\n" {
+		t.Errorf("logs transcript = %q", got)
+	}
+	if got := ParseScrollback(""); len(got) != 0 {
+		t.Errorf("ParseScrollback(\"\") = %v, want empty", got)
+	}
+}
+
+// TestScrollbackScript captures history beyond the visible pane from a private tmux server
+func TestScrollbackScript(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	env := append(os.Environ(), "TMUX="+socket+",0,0") // Bare "tmux" commands use this server
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("tmux", append([]string{"-f", "/dev/null"}, args...)...)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("tmux %v failed: %v\n%s", args, err, output)
+		}
+	}
+	t.Cleanup(func() {
+		cmd := exec.Command("tmux", "kill-server")
+		cmd.Env = env
+		_ = cmd.Run()
+	})
+
+	run("new-session", "-d", "-s", "agent", "-x", "80", "-y", "10", "seq 1 200; exec sleep 60")
+	run("split-window", "-d", "-t", "agent:", "exec sleep 60")
+	run("new-session", "-d", "-s", "other", "exec sleep 60")
+
+	capture := func(session string) map[string]string {
+		t.Helper()
+		cmd := exec.Command("sh", "-c", ScrollbackScript, "sh", session)
+		cmd.Env = env
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("scrollback script failed: %v", err)
+		}
+		return ParseScrollback(string(output))
+	}
+
+	// Give the session commands a moment to run
+	var transcripts map[string]string
+	for range 20 {
+		transcripts = capture("agent")
+		if strings.Contains(transcripts["agent"], "\n200\n") {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	agent := transcripts["agent"]
+	if !strings.Contains(agent, "=== 0.0: ") || !strings.Contains(agent, "\n1\n2\n") || !strings.Contains(agent, "\n200\n") {
+		t.Errorf("agent transcript should hold the full scrollback, got %q", agent)
+	}
+	if !strings.Contains(agent, "=== 0.1: sleep ===") {
+		t.Errorf("agent transcript should include the second pane, got %q", agent)
+	}
+	if _, ok := transcripts["other"]; ok {
+		t.Error("capturing one session should not include other sessions")
+	}
+
+	if all := capture(""); len(all) != 2 {
+		t.Errorf("capturing all sessions got %d transcripts, want 2", len(all))
+	}
+}
@@ -0,0 +1,73 @@
+package tmux
+
+import "sort"
+
+// setupConfScript pipes its first argument to the tmux command line formed by the
+// remaining arguments, which includes "source-file -"
+const setupConfScript = `conf=$1; shift; printf '%s\n' "$conf" | tmux "$@"`
+
+// SessionSetup describes a new session and everything claude-quick sets up in it
+type SessionSetup struct {
+	Name          string
+	Env           map[string]string // Environment of the session's shells, including later windows
+	Template      *Template         // Windows and panes to build; nil for a single window
+	LaunchCommand string            // Run in the template's launch panes, or the single window
+	Options       map[string]string // Extra session options, e.g. user options recording the template
+	Style         Style             // Theme options and tmux.conf snippet, applied last
+}
+
+// Commands returns the tmux commands (without the leading "tmux") that create and
+// set up the session, in order: the session itself with its environment, options,
+// the template layout or launch command, and the style's options.
+func (s SessionSetup) Commands() [][]string {
+	envNames := sortedKeys(s.Env)
+
+	newSession := []string{"new-session", "-d", "-s", s.Name}
+	for _, name := range envNames {
+		newSession = append(newSession, "-e", name+"="+s.Env[name])
+	}
+	if s.Template != nil {
+		newSession = append(newSession, s.Template.NewSessionArgs()...)
+	}
+	cmds := [][]string{newSession}
+
+	// -e only reaches the initial shell; setenv also covers windows created later
+	for _, name := range envNames {
+		cmds = append(cmds, []string{"setenv", "-t", s.Name, name, s.Env[name]})
+	}
+	for _, name := range sortedKeys(s.Options) {
+		cmds = append(cmds, []string{"set-option", "-t", s.Name, name, s.Options[name]})
+	}
+
+	switch {
+	case s.Template != nil:
+		cmds = append(cmds, s.Template.Commands(s.Name, s.LaunchCommand)...)
+	case s.LaunchCommand != "":
+		cmds = append(cmds, SendTextCommands(s.Name, s.LaunchCommand)...)
+	}
+
+	return append(cmds, s.Style.Commands(s.Name)...)
+}
+
+// ExecArgs returns the command line that sets up the session in a single exec:
+// one tmux invocation running all Commands. A style's tmux.conf snippet is
+// passed in through a shell, which pipes it to the source-file among them. tmux
+// stops at the first failing command, so nothing is applied to an existing
+// session of the same name.
+func (s SessionSetup) ExecArgs() []string {
+	cmds := s.Commands()
+	if s.Style.Conf == "" {
+		return append([]string{"tmux"}, JoinCommands(cmds)...)
+	}
+	return append([]string{"sh", "-c", setupConfScript, "sh", s.Style.Conf}, JoinCommands(cmds)...)
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
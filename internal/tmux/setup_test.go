@@ -0,0 +1,192 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSessionSetup_Commands(t *testing.T) {
+	twoWindows := &Template{Name: "two", Windows: []WindowTemplate{{Name: "agent", Panes: []PaneTemplate{{Launch: true}}}, {Name: "shell"}}}
+	tests := []struct {
+		name  string
+		setup SessionSetup
+		want  [][]string
+	}{
+		{
+			name:  "bare session",
+			setup: SessionSetup{Name: "main"},
+			want:  [][]string{{"new-session", "-d", "-s", "main"}, observeLabelCommand("main")},
+		},
+		{
+			name: "single window with launch command",
+			setup: SessionSetup{
+				Name:          "main",
+				Env:           map[string]string{"TOKEN": "a b", "API_KEY": "k"},
+				LaunchCommand: "claude",
+				Style:         Style{Options: map[string]string{"status-style": "bg=red"}},
+			},
+			want: [][]string{
+				{"new-session", "-d", "-s", "main", "-e", "API_KEY=k", "-e", "TOKEN=a b"},
+				{"setenv", "-t", "main", "API_KEY", "k"},
+				{"setenv", "-t", "main", "TOKEN", "a b"},
+				{"send-keys", "-t", "main:", "-l", "--", "claude"},
+				{"send-keys", "-t", "main:", "Enter"},
+				{"set-option", "-t", "main", "status-style", "bg=red"},
+				observeLabelCommand("main"),
+			},
+		},
+		{
+			name: "template",
+			setup: SessionSetup{
+				Name:          "dev",
+				Template:      twoWindows,
+				Options:       map[string]string{"@template": "two"},
+				LaunchCommand: "claude",
+			},
+			// The layout itself is covered by the template tests
+			want: append(append([][]string{
+				{"new-session", "-d", "-s", "dev", "-n", "agent"},
+				{"set-option", "-t", "dev", "@template", "two"},
+			}, twoWindows.Commands("dev", "claude")...), observeLabelCommand("dev")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.setup.Commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Commands() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionSetup_ExecArgs(t *testing.T) {
+	setup := SessionSetup{Name: "main", LaunchCommand: "echo hi;"}
+	want := append([]string{"tmux", "new-session", "-d", "-s", "main", ";", "send-keys", "-t", "main:", "-l", "--", `echo hi\;`, ";",
+		"send-keys", "-t", "main:", "Enter", ";"},
+		observeLabelCommand("main")...)
+	if got := setup.ExecArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExecArgs() = %q, want %q", got, want)
+	}
+
+	setup.Style.Conf = "set -g mouse on"
+	got := setup.ExecArgs()
+	if got[0] != "sh" || got[4] != "set -g mouse on" {
+		t.Errorf("ExecArgs() with a snippet should pass it to the shell, got %q", got)
+	}
+	if !strings.Contains(strings.Join(got, " "), "; source-file - ;") {
+		t.Errorf("ExecArgs() with a snippet should source stdin, got %q", got)
+	}
+}
+
+// TestSessionSetup_Tmux runs the setup against a private tmux server
+func TestSessionSetup_Tmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	env := append(os.Environ(), "TMUX="+socket+",0,0") // Bare "tmux" commands use this server
+	tmux := func(args ...string) (string, error) {
+		cmd := exec.Command("tmux", args...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(output)), err
+	}
+	t.Cleanup(func() { _, _ = tmux("kill-server") })
+	// Start the server without the user's tmux.conf
+	if output, err := tmux("-f", "/dev/null", "new-session", "-d", "-s", "other"); err != nil {
+		t.Fatalf("starting tmux failed: %v\n%s", err, output)
+	}
+
+	setup := SessionSetup{
+		Name:     "agent",
+		Env:      map[string]string{"SECRET": "it's \"quoted\";"},
+		Template: &Template{Name: "two", Windows: []WindowTemplate{{Name: "main"}, {Name: "shell"}}},
+		Options:  map[string]string{"@template": "two"},
+		Style: Style{
+			Options: AnthropicTheme().Variant(true),
+			Conf:    "set -g @snippet 'sourced'\nset -g mouse on\n",
+		},
+	}
+	run := func() (string, error) {
+		args := setup.ExecArgs()
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+	if output, err := run(); err != nil {
+		t.Fatalf("setup failed: %v\n%s", err, output)
+	}
+
+	checks := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"show-environment", "-t", "agent", "SECRET"}, `SECRET=it's "quoted";`},
+		{[]string{"show-options", "-t", "agent", "-qv", "@template"}, "two"},
+		{[]string{"show-options", "-t", "agent", "-v", "status-style"}, "bg=#D97706,fg=#FFFFFF"},
+		{[]string{"show-options", "-gv", "@snippet"}, "sourced"},
+		{[]string{"show-options", "-t", "agent", "-v", "status-left"}, ObserveLabel + " #S"}, // Output is trimmed
+		{[]string{"list-windows", "-t", "agent", "-F", "#{window_name}"}, "main\nshell"},
+	}
+	for _, c := range checks {
+		if got, err := tmux(c.args...); err != nil || got != c.want {
+			t.Errorf("tmux %q = %q, %v, want %q", c.args, got, err, c.want)
+		}
+	}
+
+	// A second setup of the same name fails before touching the existing session
+	setup.Options = map[string]string{"@template": "changed"}
+	if _, err := run(); err == nil {
+		t.Error("setting up an existing session should fail")
+	}
+	if got, _ := tmux("show-options", "-t", "agent", "-qv", "@template"); got != "two" {
+		t.Errorf("existing session was modified: @template = %q", got)
+	}
+}
+
+// TestSessionSetup_ObserveLabel_Tmux checks that observers are labelled without a
+// theme, on top of a status-left set by the tmux.conf snippet
+func TestSessionSetup_ObserveLabel_Tmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	env := append(os.Environ(), "TMUX="+socket+",0,0") // Bare "tmux" commands use this server
+	tmux := func(args ...string) (string, error) {
+		cmd := exec.Command("tmux", args...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(output)), err
+	}
+	t.Cleanup(func() { _, _ = tmux("kill-server") })
+	if output, err := tmux("-f", "/dev/null", "new-session", "-d", "-s", "other"); err != nil {
+		t.Fatalf("starting tmux failed: %v\n%s", err, output)
+	}
+
+	none, _ := BuiltinTheme(ThemeNone)
+	// The snippet sets the global status-left, so the plain session goes first
+	cases := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{"plain", Style{Options: none.Variant(true)}, ObserveLabel + "[#{session_name}]"}, // tmux's default, trimmed
+		{"conf", Style{Conf: "set -g status-left '[conf] #S #(echo hi)'\n"}, ObserveLabel + "[conf] #S #(echo hi)"},
+	}
+	for _, tc := range cases {
+		args := SessionSetup{Name: tc.name, Style: tc.style}.ExecArgs()
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("setup %s failed: %v\n%s", tc.name, err, output)
+		}
+		if got, err := tmux("show-options", "-t", tc.name, "-v", "status-left"); err != nil || got != tc.want {
+			t.Errorf("%s status-left = %q, %v, want %q", tc.name, got, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tmux
+
+import (
+	"path"
+	"strings"
+)
+
+// AgentCommand is the program recognized as the agent when a layout is saved
+const AgentCommand = "claude"
+
+// SavedSession is the layout of a session recorded so it can be recreated later
+type SavedSession struct {
+	Name     string           `yaml:"name"`
+	Template string           `yaml:"template,omitempty"` // Template the session was created from
+	Windows  []WindowTemplate `yaml:"windows"`
+}
+
+// Layout returns the saved windows and panes as a template for CreateTmuxSession,
+// named after the template the session was created from
+func (s SavedSession) Layout() Template {
+	return Template{Name: s.Template, Windows: s.Windows}
+}
+
+// SnapshotScript returns a shell script that prints one line per pane of every
+// session: the pane's pid, a space, then tab separated the session name, window
+// index, window and pane active flags, window name (empty if tmux names it
+// automatically), window layout, working directory, foreground command, the
+// session's templateOption and the foreground process's command line. See
+// ParseSnapshot.
+func SnapshotScript(templateOption string) string {
+	format := "#{pane_pid} #{session_name}\t#{window_index}\t#{window_active}#{pane_active}\t" +
+		"#{?automatic-rename,,#{window_name}}\t#{window_layout}\t#{pane_current_path}\t" +
+		"#{pane_current_command}\t#{" + templateOption + "}"
+	return "tmux list-panes -a -F '" + format + "' |\n" +
+		"while IFS= read -r line; do\n" +
+		"\tpid=${line%% *}\n" +
+		"\tchild=$(cut -d' ' -f1 /proc/$pid/task/$pid/children 2>/dev/null)\n" +
+		"\targs=$(tr '\\0' ' ' < /proc/${child:-$pid}/cmdline 2>/dev/null)\n" +
+		"\tprintf '%s\\t%s\\n' \"$line\" \"$args\"\n" +
+		"done\n"
+}
+
+// ParseSnapshot parses SnapshotScript output into the sessions' layouts. Panes
+// running the agent are marked to launch it; panes running another program keep
+// its command line, and panes at a shell prompt only keep their directory.
+func ParseSnapshot(output string) []SavedSession {
+	var sessions []SavedSession
+	lastWindow := ""
+	for _, line := range strings.Split(output, "\n") {
+		_, rest, ok := strings.Cut(line, " ")
+		fields := strings.Split(rest, "\t")
+		if !ok || len(fields) < 9 {
+			continue
+		}
+		name, window, active := fields[0], fields[1], fields[2]
+		command, args := fields[6], strings.TrimSpace(fields[8])
+
+		if len(sessions) == 0 || sessions[len(sessions)-1].Name != name {
+			sessions = append(sessions, SavedSession{Name: name, Template: fields[7]})
+			lastWindow = ""
+		}
+		s := &sessions[len(sessions)-1]
+		if window != lastWindow {
+			s.Windows = append(s.Windows, WindowTemplate{Name: fields[3], Layout: fields[4]})
+			lastWindow = window
+		}
+		w := &s.Windows[len(s.Windows)-1]
+
+		pane := PaneTemplate{Dir: fields[5], Focus: active == "11"}
+		switch {
+		case isAgent(command, args):
+			pane.Launch = true
+		case !shells[command]:
+			pane.Command = args
+		}
+		w.Panes = append(w.Panes, pane)
+	}
+	return sessions
+}
+
+// isAgent reports whether a pane's foreground program is the agent, run directly
+// or through an interpreter such as node
+func isAgent(command, args string) bool {
+	if command == AgentCommand {
+		return true
+	}
+	for _, arg := range strings.Fields(args) {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		if path.Base(arg) == AgentCommand {
+			return true
+		}
+	}
+	return false
+}
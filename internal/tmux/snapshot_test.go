@@ -0,0 +1,131 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSnapshot(t *testing.T) {
+	output := strings.Join([]string{
+		"101 main\t0\t11\t\tlayout-a\t/work\tnode\tagent\tnode /usr/local/bin/claude --resume",
+		"102 main\t0\t10\t\tlayout-a\t/work/src\tnpm\tagent\tnpm run watch ",
+		"103 main\t1\t00\tshell\tlayout-b\t/work\tbash\tagent\tbash ",
+		"201 logs\t0\t11\t\tlayout-c\t/var/log\ttail\t\ttail -f app.log ",
+		"garbage",
+		"",
+	}, "\n")
+
+	want := []SavedSession{
+		{Name: "main", Template: "agent", Windows: []WindowTemplate{
+			{Layout: "layout-a", Panes: []PaneTemplate{
+				{Dir: "/work", Launch: true, Focus: true},
+				{Dir: "/work/src", Command: "npm run watch"},
+			}},
+			{Name: "shell", Layout: "layout-b", Panes: []PaneTemplate{{Dir: "/work"}}},
+		}},
+		{Name: "logs", Windows: []WindowTemplate{
+			{Layout: "layout-c", Panes: []PaneTemplate{{Dir: "/var/log", Command: "tail -f app.log", Focus: true}}},
+		}},
+	}
+	if got := ParseSnapshot(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSnapshot() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIsAgent(t *testing.T) {
+	tests := []struct {
+		command, args string
+		want          bool
+	}{
+		{"claude", "claude", true},
+		{"node", "node /usr/local/bin/claude --continue", true},
+		{"node", "node server.js --name claude", false},
+		{"vim", "vim claude.md", false},
+		{"zsh", "", false},
+	}
+	for _, tt := range tests {
+		if got := isAgent(tt.command, tt.args); got != tt.want {
+			t.Errorf("isAgent(%q, %q) = %v, want %v", tt.command, tt.args, got, tt.want)
+		}
+	}
+}
+
+// TestSnapshotScript saves a session from a private tmux server and rebuilds it
+// from the saved layout
+func TestSnapshotScript(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	env := append(os.Environ(), "TMUX="+socket+",0,0") // Bare "tmux" commands use this server
+	tmux := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("tmux", append([]string{"-f", "/dev/null"}, args...)...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("tmux %q failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	t.Cleanup(func() {
+		cmd := exec.Command("tmux", "kill-server")
+		cmd.Env = env
+		_ = cmd.Run()
+	})
+
+	dir := t.TempDir()
+	tmux("new-session", "-d", "-s", "work", "-x", "120", "-y", "40", "-c", dir, "sleep 300")
+	tmux("split-window", "-d", "-v", "-l", "10", "-t", "work:", "-c", dir)
+	tmux("new-window", "-d", "-t", "work:", "-n", "notes", "-c", dir)
+	tmux("set-option", "-t", "work", "@template", "agent")
+
+	snapshot := func() []SavedSession {
+		t.Helper()
+		cmd := exec.Command("sh", "-c", SnapshotScript("@template"))
+		cmd.Env = env
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("snapshot failed: %v", err)
+		}
+		return ParseSnapshot(string(output))
+	}
+
+	// Give the shells a moment to start
+	var saved []SavedSession
+	for range 20 {
+		saved = snapshot()
+		if len(saved) == 1 && len(saved[0].Windows) == 2 && saved[0].Windows[0].Panes[0].Command != "" {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(saved) != 1 || saved[0].Name != "work" || saved[0].Template != "agent" || len(saved[0].Windows) != 2 {
+		t.Fatalf("saved = %+v, want session work with 2 windows", saved)
+	}
+	first, second := saved[0].Windows[0], saved[0].Windows[1]
+	if len(first.Panes) != 2 || first.Panes[0].Command != "sleep 300" || first.Panes[1].Command != "" || first.Panes[0].Dir != dir {
+		t.Errorf("first window = %+v, want sleep and a shell in %s", first, dir)
+	}
+	if first.Name != "" || second.Name != "notes" {
+		t.Errorf("window names = %q, %q, want automatic and notes", first.Name, second.Name)
+	}
+
+	// Rebuilding the layout restores the pane sizes
+	layout := saved[0].Layout()
+	tmux(append([]string{"new-session", "-d", "-s", "copy", "-x", "120", "-y", "40"}, layout.NewSessionArgs()...)...)
+	for _, c := range layout.Commands("copy", "") {
+		tmux(c...)
+	}
+	sizes := func(target string) string {
+		return tmux("list-panes", "-t", target, "-F", "#{pane_width}x#{pane_height}")
+	}
+	if got, want := sizes("copy:^"), sizes("work:^"); got != want {
+		t.Errorf("rebuilt pane sizes = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package tmux
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Pane split directions
+const (
+	SplitRight = "right" // New pane to the right of the previous one (default)
+	SplitBelow = "below" // New pane below the previous one
+)
+
+// sizePattern matches pane sizes accepted by "split-window -l": cells or a percentage
+var sizePattern = regexp.MustCompile(`^[1-9][0-9]*%?$`)
+
+// Template describes the windows and panes of a new session
+type Template struct {
+	Name    string           `yaml:"-"` // Name the template is configured under
+	Windows []WindowTemplate `yaml:"windows"`
+}
+
+// WindowTemplate describes one window of a session template
+type WindowTemplate struct {
+	Name  string         `yaml:"name,omitempty"`
+	Dir   string         `yaml:"dir,omitempty"` // Working directory, relative to the workspace folder
+	Panes []PaneTemplate `yaml:"panes,omitempty"`
+	// Layout is applied once the panes exist: a tmux layout such as "tiled", or a
+	// saved window_layout string restoring the exact pane sizes
+	Layout string `yaml:"layout,omitempty"`
+}
+
+// PaneTemplate describes one pane of a window. The first pane fills the window,
+// each further pane is split off the previous one.
+type PaneTemplate struct {
+	Split   string `yaml:"split,omitempty"`   // "right" (default) or "below"
+	Size    string `yaml:"size,omitempty"`    // Size of the new pane, e.g. "40%" or "20" (cells)
+	Dir     string `yaml:"dir,omitempty"`     // Working directory, defaults to the window's
+	Command string `yaml:"command,omitempty"` // Command typed into the pane
+	Launch  bool   `yaml:"launch,omitempty"`  // Run the configured launch_command in this pane
+	Focus   bool   `yaml:"focus,omitempty"`   // Select this pane once the session is built
+}
+
+// Validate checks that the template can be built
+func (t Template) Validate() error {
+	if len(t.Windows) == 0 {
+		return fmt.Errorf("template has no windows")
+	}
+	focused := 0
+	for i, w := range t.Windows {
+		for j, p := range w.Panes {
+			switch p.Split {
+			case "", SplitRight, SplitBelow:
+			default:
+				return fmt.Errorf("window %d pane %d: invalid split %q (must be %q or %q)", i+1, j+1, p.Split, SplitRight, SplitBelow)
+			}
+			if p.Size != "" && !sizePattern.MatchString(p.Size) {
+				return fmt.Errorf("window %d pane %d: invalid size %q (use cells or a percentage like 40%%)", i+1, j+1, p.Size)
+			}
+			if p.Launch && p.Command != "" {
+				return fmt.Errorf("window %d pane %d: set either command or launch, not both", i+1, j+1)
+			}
+			if p.Focus {
+				focused++
+			}
+		}
+	}
+	if focused > 1 {
+		return fmt.Errorf("only one pane can have focus")
+	}
+	return nil
+}
+
+// panes returns the window's panes, treating a window without panes as one empty pane
+func (w WindowTemplate) panes() []PaneTemplate {
+	if len(w.Panes) == 0 {
+		return []PaneTemplate{{}}
+	}
+	return w.Panes
+}
+
+// focus returns the window and pane index of the focused pane (the first pane by default)
+func (t Template) focus() (window, pane int) {
+	for i, w := range t.Windows {
+		for j, p := range w.panes() {
+			if p.Focus {
+				return i, j
+			}
+		}
+	}
+	return 0, 0
+}
+
+// NewSessionArgs returns the "tmux new-session" arguments that shape the first window
+func (t Template) NewSessionArgs() []string {
+	if len(t.Windows) == 0 {
+		return nil
+	}
+	first := t.Windows[0]
+	var args []string
+	if first.Name != "" {
+		args = append(args, "-n", first.Name)
+	}
+	if dir := first.panes()[0].dir(first); dir != "" {
+		args = append(args, "-c", dir)
+	}
+	return args
+}
+
+// dir returns the pane's working directory, falling back to the window's
+func (p PaneTemplate) dir(w WindowTemplate) string {
+	if p.Dir != "" {
+		return p.Dir
+	}
+	return w.Dir
+}
+
+// Commands returns the tmux commands (without the leading "tmux") that build the
+// template in a session just created with NewSessionArgs. Panes with Launch set
+// run launchCommand.
+//
+// Every command targets the session's current window and pane, which is always
+// the one created last, so no window or pane indexes (which depend on the user's
+// base-index settings) are needed. The focused pane is found again through the
+// tmux marked pane, which is cleared at the end.
+func (t Template) Commands(session, launchCommand string) [][]string {
+	target := session + ":"
+	focusWindow, focusPane := t.focus()
+
+	var cmds [][]string
+	for i, w := range t.Windows {
+		for j, p := range w.panes() {
+			switch {
+			case i > 0 && j == 0:
+				cmd := []string{"new-window", "-t", target}
+				if w.Name != "" {
+					cmd = append(cmd, "-n", w.Name)
+				}
+				if dir := p.dir(w); dir != "" {
+					cmd = append(cmd, "-c", dir)
+				}
+				cmds = append(cmds, cmd)
+			case j > 0:
+				cmd := []string{"split-window", "-t", target, "-h"}
+				if p.Split == SplitBelow {
+					cmd[3] = "-v"
+				}
+				if p.Size != "" {
+					cmd = append(cmd, "-l", p.Size)
+				}
+				if dir := p.dir(w); dir != "" {
+					cmd = append(cmd, "-c", dir)
+				}
+				cmds = append(cmds, cmd)
+			}
+
+			command := p.Command
+			if p.Launch {
+				command = launchCommand
+			}
+			if command != "" {
+				cmds = append(cmds, SendTextCommands(session, command)...)
+			}
+			if i == focusWindow && j == focusPane {
+				cmds = append(cmds, []string{"select-pane", "-t", target, "-m"})
+			}
+		}
+		if w.Layout != "" {
+			cmds = append(cmds, []string{"select-layout", "-t", target, w.Layout})
+		}
+	}
+
+	return append(cmds,
+		[]string{"select-window", "-t", "{marked}"},
+		[]string{"select-pane", "-t", "{marked}"},
+		[]string{"select-pane", "-M"},
+	)
+}
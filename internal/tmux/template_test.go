@@ -0,0 +1,152 @@
+package tmux
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// agentTemplate is Claude on the left, a test watcher on the right and a shell in window 2
+var agentTemplate = Template{
+	Name: "agent",
+	Windows: []WindowTemplate{
+		{
+			Name: "agent",
+			Panes: []PaneTemplate{
+				{Launch: true, Focus: true},
+				{Split: SplitRight, Size: "40%", Dir: "src", Command: "make watch"},
+			},
+		},
+		{Name: "shell"},
+	},
+}
+
+func TestTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    Template
+		wantErr bool
+	}{
+		{"agent template", agentTemplate, false},
+		{"no windows", Template{}, true},
+		{"bad split", Template{Windows: []WindowTemplate{{Panes: []PaneTemplate{{}, {Split: "left"}}}}}, true},
+		{"bad size", Template{Windows: []WindowTemplate{{Panes: []PaneTemplate{{}, {Size: "half"}}}}}, true},
+		{"cells size", Template{Windows: []WindowTemplate{{Panes: []PaneTemplate{{}, {Size: "20"}}}}}, false},
+		{"command and launch", Template{Windows: []WindowTemplate{{Panes: []PaneTemplate{{Launch: true, Command: "claude"}}}}}, true},
+		{"two focused panes", Template{Windows: []WindowTemplate{{Panes: []PaneTemplate{{Focus: true}}}, {Panes: []PaneTemplate{{Focus: true}}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tmpl.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplate_NewSessionArgs(t *testing.T) {
+	tmpl := Template{Windows: []WindowTemplate{{Name: "code", Dir: "app", Panes: []PaneTemplate{{Dir: "app/api"}}}}}
+	want := []string{"-n", "code", "-c", "app/api"}
+	if got := tmpl.NewSessionArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewSessionArgs() = %v, want %v", got, want)
+	}
+	if got := (Template{Windows: []WindowTemplate{{}}}).NewSessionArgs(); len(got) != 0 {
+		t.Errorf("NewSessionArgs() = %v, want none", got)
+	}
+}
+
+func TestTemplate_Commands(t *testing.T) {
+	want := [][]string{
+		{"send-keys", "-t", "dev:", "-l", "--", "claude"},
+		{"send-keys", "-t", "dev:", "Enter"},
+		{"select-pane", "-t", "dev:", "-m"},
+		{"split-window", "-t", "dev:", "-h", "-l", "40%", "-c", "src"},
+		{"send-keys", "-t", "dev:", "-l", "--", "make watch"},
+		{"send-keys", "-t", "dev:", "Enter"},
+		{"new-window", "-t", "dev:", "-n", "shell"},
+		{"select-window", "-t", "{marked}"},
+		{"select-pane", "-t", "{marked}"},
+		{"select-pane", "-M"},
+	}
+	if got := agentTemplate.Commands("dev", "claude"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Commands() =\n%v\nwant\n%v", got, want)
+	}
+
+	// Without a launch command, launch panes are left at the shell
+	for _, cmd := range agentTemplate.Commands("dev", "") {
+		if cmd[0] == "send-keys" && cmd[3] == "-l" && cmd[5] != "make watch" {
+			t.Errorf("unexpected send-keys %v with empty launch command", cmd)
+		}
+	}
+}
+
+// TestTemplate_Commands_TypesLiterally checks that pane commands are typed as
+// text, not read as send-keys flags or key names
+func TestTemplate_Commands_TypesLiterally(t *testing.T) {
+	tmpl := Template{Windows: []WindowTemplate{{Panes: []PaneTemplate{{Command: "-x"}, {Command: "Enter"}}}}}
+	cmds := tmpl.Commands("s", "")
+	want := [][]string{
+		{"send-keys", "-t", "s:", "-l", "--", "-x"},
+		{"send-keys", "-t", "s:", "Enter"},
+		{"select-pane", "-t", "s:", "-m"},
+		{"split-window", "-t", "s:", "-h"},
+		{"send-keys", "-t", "s:", "-l", "--", "Enter"},
+		{"send-keys", "-t", "s:", "Enter"},
+	}
+	if !reflect.DeepEqual(cmds[:len(want)], want) {
+		t.Errorf("Commands() = %q, want %q first", cmds, want)
+	}
+}
+
+// TestTemplate_BuildsInTmux builds a template on a private tmux server
+func TestTemplate_BuildsInTmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "tmux.sock")
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("tmux", append([]string{"-S", socket, "-f", "/dev/null"}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("tmux %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	t.Cleanup(func() { _ = exec.Command("tmux", "-S", socket, "kill-server").Run() })
+
+	tmpl := Template{Windows: []WindowTemplate{
+		{Name: "agent", Panes: []PaneTemplate{{}, {Split: SplitBelow, Focus: true}}},
+		{Name: "shell", Panes: []PaneTemplate{{Command: "-x"}}}, // Not a send-keys flag
+	}}
+	run(append([]string{"new-session", "-d", "-s", "dev"}, tmpl.NewSessionArgs()...)...)
+	run("set-option", "-g", "base-index", "1") // Must not affect targeting
+	for _, cmd := range tmpl.Commands("dev", "") {
+		run(cmd...)
+	}
+
+	panes := run("list-panes", "-s", "-t", "dev", "-F", "#{window_name}:#{window_active}:#{pane_active}:#{pane_marked}")
+	want := "agent:1:0:0\nagent:1:1:0\nshell:0:1:0"
+	if panes != want {
+		t.Errorf("panes =\n%s\nwant\n%s", panes, want)
+	}
+}
+
+func TestTemplate_Commands_Layout(t *testing.T) {
+	tmpl := Template{Windows: []WindowTemplate{
+		{Layout: "tiled", Panes: []PaneTemplate{{}, {Split: SplitBelow}}},
+		{Name: "shell"},
+	}}
+	cmds := tmpl.Commands("s", "")
+	want := []string{"select-layout", "-t", "s:", "tiled"}
+	// The layout follows the window's last pane and comes before the next window
+	if !reflect.DeepEqual(cmds[2], want) || cmds[3][0] != "new-window" {
+		t.Errorf("Commands() = %q, want %q after the split", cmds, want)
+	}
+}
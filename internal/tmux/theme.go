@@ -0,0 +1,129 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Built-in theme names
+const (
+	ThemeAnthropic = "anthropic" // Orange status bar with git branch display (default)
+	ThemeNone      = "none"      // No styling, leaving the container's own tmux.conf in effect
+)
+
+// Theme is a set of session options applied to the sessions claude-quick creates,
+// with variants for dark and light terminals
+type Theme struct {
+	Options map[string]string `yaml:"options,omitempty"` // Applied in both modes
+	Dark    map[string]string `yaml:"dark,omitempty"`    // Applied on top of Options in dark mode
+	Light   map[string]string `yaml:"light,omitempty"`   // Applied on top of Options in light mode
+}
+
+// Style is how new sessions are styled: the options of a theme variant plus an
+// optional tmux.conf snippet sourced after them
+type Style struct {
+	Options map[string]string // Session options, nil for none
+	Conf    string            // tmux.conf snippet, empty for none
+}
+
+// AnthropicTheme returns the default theme: Anthropic orange (#D97706) as the primary
+// color with git branch display
+func AnthropicTheme() Theme {
+	return Theme{
+		Options: map[string]string{
+			// Status left: session name with padding
+			"status-left":        " #S ",
+			"status-left-length": "40",
+			// Status right: git branch + window/pane info
+			"status-right": " #(git -C #{pane_current_path} rev-parse --abbrev-ref HEAD 2>/dev/null || echo 'no-branch') │ #I:#P ",
+			// Window list
+			"window-status-current-format": " #I:#W ",
+			"window-status-format":         " #I:#W ",
+		},
+		Dark: map[string]string{
+			"status-style":                "bg=#D97706,fg=#FFFFFF",
+			"status-left-style":           "bg=#B45309,fg=#FFFFFF,bold",
+			"status-right-style":          "bg=#B45309,fg=#FFFFFF",
+			"window-status-current-style": "bg=#FFFFFF,fg=#D97706,bold",
+			"window-status-style":         "fg=#FFF7ED",
+			"pane-border-style":           "fg=#D97706",
+			"pane-active-border-style":    "fg=#F97316",
+		},
+		Light: map[string]string{
+			"status-style":                "bg=#FFEDD5,fg=#9A3412",
+			"status-left-style":           "bg=#FDBA74,fg=#7C2D12,bold",
+			"status-right-style":          "bg=#FDBA74,fg=#7C2D12",
+			"window-status-current-style": "bg=#D97706,fg=#FFFFFF,bold",
+			"window-status-style":         "fg=#9A3412",
+			"pane-border-style":           "fg=#FDBA74",
+			"pane-active-border-style":    "fg=#D97706",
+		},
+	}
+}
+
+// BuiltinTheme returns a built-in theme by name. The "none" theme has no options.
+func BuiltinTheme(name string) (Theme, bool) {
+	switch name {
+	case ThemeAnthropic:
+		return AnthropicTheme(), true
+	case ThemeNone:
+		return Theme{}, true
+	}
+	return Theme{}, false
+}
+
+// Validate checks that the theme only sets option names
+func (t Theme) Validate() error {
+	for _, options := range []map[string]string{t.Options, t.Dark, t.Light} {
+		for name := range options {
+			if name == "" {
+				return fmt.Errorf("empty option name")
+			}
+		}
+	}
+	return nil
+}
+
+// Variant returns the theme's options for a dark or light terminal
+func (t Theme) Variant(dark bool) map[string]string {
+	overrides := t.Light
+	if dark {
+		overrides = t.Dark
+	}
+	if len(t.Options) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	options := make(map[string]string, len(t.Options)+len(overrides))
+	for name, value := range t.Options {
+		options[name] = value
+	}
+	for name, value := range overrides {
+		options[name] = value
+	}
+	return options
+}
+
+// Commands returns the tmux commands (without the leading "tmux") that apply the
+// style to a session: its options in name order, then sourcing the tmux.conf
+// snippet from stdin if there is one, and finally ObserveLabel prepended to the
+// status-left those leave the session with, so observers are labelled whatever
+// the theme or snippet
+func (s Style) Commands(session string) [][]string {
+	names := sortedKeys(s.Options)
+	cmds := make([][]string, 0, len(names)+2)
+	for _, name := range names {
+		cmds = append(cmds, []string{"set-option", "-t", session, name, s.Options[name]})
+	}
+	if s.Conf != "" {
+		cmds = append(cmds, []string{"source-file", "-"})
+	}
+	return append(cmds, observeLabelCommand(session))
+}
+
+// observeLabelCommand prepends ObserveLabel to a session's status-left. The value is
+// expanded once (-F) to read the current status-left, which is inserted as is;
+// the label's own format is escaped to survive that expansion.
+func observeLabelCommand(session string) []string {
+	label := strings.ReplaceAll(ObserveLabel, "#{", "##{")
+	return []string{"set-option", "-F", "-t", session, "status-left", label + "#{status-left}"}
+}
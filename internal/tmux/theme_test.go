@@ -0,0 +1,92 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTheme_Variant(t *testing.T) {
+	theme := Theme{
+		Options: map[string]string{"status-left": " #S ", "status-style": "bg=black"},
+		Dark:    map[string]string{"status-style": "bg=orange"},
+		Light:   map[string]string{"status-style": "bg=white", "pane-border-style": "fg=grey"},
+	}
+	tests := []struct {
+		name string
+		dark bool
+		want map[string]string
+	}{
+		{"dark", true, map[string]string{"status-left": " #S ", "status-style": "bg=orange"}},
+		{"light", false, map[string]string{"status-left": " #S ", "status-style": "bg=white", "pane-border-style": "fg=grey"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := theme.Variant(tt.dark); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Variant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	none, ok := BuiltinTheme(ThemeNone)
+	if !ok || none.Variant(true) != nil {
+		t.Errorf("the none theme should have no options, got %v", none.Variant(true))
+	}
+	if _, ok := BuiltinTheme("missing"); ok {
+		t.Error("BuiltinTheme() should not find unknown themes")
+	}
+}
+
+func TestStyle_Commands(t *testing.T) {
+	style := Style{Options: map[string]string{"status-style": "bg=red", "status-left": " #S "}}
+	want := [][]string{
+		{"set-option", "-t", "main", "status-left", " #S "},
+		{"set-option", "-t", "main", "status-style", "bg=red"},
+		observeLabelCommand("main"),
+	}
+	if got := style.Commands("main"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Commands() = %q, want %q", got, want)
+	}
+
+	// The label comes after the snippet, and is added even without a theme
+	style.Conf = "set -g mouse on"
+	if got := style.Commands("main"); !reflect.DeepEqual(got[2], []string{"source-file", "-"}) || !reflect.DeepEqual(got[3], want[2]) {
+		t.Errorf("Commands() with a snippet = %q, want source-file then the label", got)
+	}
+	none, _ := BuiltinTheme(ThemeNone)
+	if got := (Style{Options: none.Variant(true)}).Commands("main"); !reflect.DeepEqual(got, want[2:]) {
+		t.Errorf("Commands() with the none theme = %q, want only the observe label", got)
+	}
+}
+
+// TestAnthropicTheme_Tmux applies both variants of the default theme to a private
+// tmux server, so every option name and value must be accepted by tmux
+func TestAnthropicTheme_Tmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	env := append(os.Environ(), "TMUX="+socket+",0,0") // Bare "tmux" commands use this server
+	tmux := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("tmux", append([]string{"-f", "/dev/null"}, args...)...)
+		cmd.Env = env
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("tmux %q failed: %v\n%s", args, err, output)
+		}
+	}
+	t.Cleanup(func() {
+		cmd := exec.Command("tmux", "kill-server")
+		cmd.Env = env
+		_ = cmd.Run()
+	})
+
+	tmux("new-session", "-d", "-s", "main")
+	for _, dark := range []bool{true, false} {
+		for _, cmd := range (Style{Options: AnthropicTheme().Variant(dark)}).Commands("main") {
+			tmux(cmd...)
+		}
+	}
+}
@@ -1,4 +1,6 @@
-// Package tmux provides utilities for parsing and formatting tmux session information.
+// Package tmux provides utilities for parsing and formatting tmux session information,
+// for building session layouts from templates and for classifying what the agent
+// in a session is doing.
 package tmux
 
 import (
@@ -9,9 +11,20 @@ import (
 // Session represents a tmux session
 type Session struct {
 	Name     string
-	Attached int // Number of attached clients
+	Attached int      // Number of attached clients
+	Windows  []Window // Windows in index order (see AddWindows)
 }
 
+// Window represents a window of a tmux session
+type Window struct {
+	Index  int
+	Name   string
+	Active bool // Current window of its session
+}
+
+// WindowListFormat is the "tmux list-windows -a" format parsed by AddWindows
+const WindowListFormat = "#{session_name}\t#{window_index}\t#{window_active}\t#{window_name}"
+
 // ParseSessions parses tmux list-sessions output
 // Input format: "session_name:attached_count" per line
 func ParseSessions(output []string) []Session {
@@ -42,6 +55,63 @@ func ParseSessions(output []string) []Session {
 	return sessions
 }
 
+// AddWindows assigns the windows listed in WindowListFormat lines to their sessions
+func AddWindows(sessions []Session, output []string) []Session {
+	bySession := make(map[string]int, len(sessions))
+	for i := range sessions {
+		bySession[sessions[i].Name] = i
+	}
+	for _, line := range output {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) < 4 {
+			continue
+		}
+		i, ok := bySession[fields[0]]
+		index, err := strconv.Atoi(fields[1])
+		if !ok || err != nil {
+			continue
+		}
+		sessions[i].Windows = append(sessions[i].Windows, Window{
+			Index:  index,
+			Name:   fields[3],
+			Active: fields[2] == "1",
+		})
+	}
+	return sessions
+}
+
+// WindowTarget returns the tmux target of a session's window
+func WindowTarget(session string, index int) string {
+	return session + ":" + strconv.Itoa(index)
+}
+
+// ObserveLabel is a status bar format that labels read-only (observing) clients
+const ObserveLabel = "#{?client_readonly,#[reverse] OBSERVING (read-only) #[noreverse],}"
+
+// AttachArgs returns the tmux arguments that attach to a session or window target.
+// Observing attaches read-only, so keystrokes never reach the panes; with ignoreSize
+// the observer's terminal size is also left out of the window size (tmux 3.2+).
+func AttachArgs(target string, observe, ignoreSize bool) []string {
+	args := []string{"attach-session"}
+	if observe {
+		if ignoreSize {
+			args = append(args, "-f", "read-only,ignore-size")
+		} else {
+			args = append(args, "-r")
+		}
+	}
+	return append(args, "-t", target)
+}
+
+// FormatWindow returns a display string for a window, marking the active one like tmux does
+func (w Window) FormatWindow() string {
+	display := strconv.Itoa(w.Index) + ": " + w.Name
+	if w.Active {
+		display += "*"
+	}
+	return display
+}
+
 // FormatSession returns a display string for a session
 func (s Session) FormatSession() string {
 	if s.Attached > 0 {
@@ -49,3 +119,53 @@ func (s Session) FormatSession() string {
 	}
 	return s.Name
 }
+
+// LastLines returns up to n of the last non-blank lines of captured pane output.
+// Trailing whitespace and the empty rows below the cursor are dropped.
+func LastLines(output string, n int) []string {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// SendTextCommands returns the tmux commands (without the leading "tmux") that type
+// text into the active pane of a session and press Enter. The text is sent literally,
+// so words such as "Enter" or "C-c" are not taken as key names, and text starting
+// with "-" is not taken as flags. Empty text just presses Enter.
+func SendTextCommands(session, text string) [][]string {
+	target := session + ":"
+	var cmds [][]string
+	if text != "" {
+		cmds = append(cmds, []string{"send-keys", "-t", target, "-l", "--", text})
+	}
+	return append(cmds, []string{"send-keys", "-t", target, "Enter"})
+}
+
+// JoinCommands joins tmux commands into the arguments of a single tmux invocation,
+// separated by ";" arguments. tmux stops at the first command that fails.
+//
+// tmux treats any argument ending in ";" as a command separator, so such
+// arguments are escaped to be passed through unchanged.
+func JoinCommands(cmds [][]string) []string {
+	var args []string
+	for i, cmd := range cmds {
+		if i > 0 {
+			args = append(args, ";")
+		}
+		for _, arg := range cmd {
+			if strings.HasSuffix(arg, ";") {
+				arg = arg[:len(arg)-1] + `\;`
+			}
+			args = append(args, arg)
+		}
+	}
+	return args
+}
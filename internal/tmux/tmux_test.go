@@ -0,0 +1,134 @@
+package tmux
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLastLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		n      int
+		want   []string
+	}{
+		{"empty pane", "\n\n\n", 5, []string{}},
+		{"blank rows below cursor", "$ make test\nok  \n$ \n\n\n", 5, []string{"$ make test", "ok", "$"}},
+		{"keeps last n", "one\ntwo\nthree\nfour\n", 2, []string{"three", "four"}},
+		{"keeps inner blank lines", "one\n\ntwo\n", 5, []string{"one", "", "two"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LastLines(tt.output, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LastLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendTextCommands(t *testing.T) {
+	want := [][]string{
+		{"send-keys", "-t", "dev:", "-l", "--", "/compact"},
+		{"send-keys", "-t", "dev:", "Enter"},
+	}
+	if got := SendTextCommands("dev", "/compact"); !reflect.DeepEqual(got, want) {
+		t.Errorf("SendTextCommands() = %v, want %v", got, want)
+	}
+	if got := SendTextCommands("dev", ""); len(got) != 1 || got[0][3] != "Enter" {
+		t.Errorf("SendTextCommands() with empty text = %v, want Enter only", got)
+	}
+}
+
+func TestJoinCommands(t *testing.T) {
+	got := JoinCommands([][]string{{"a", "1"}, {"b"}, {"c", "ls;"}})
+	want := []string{"a", "1", ";", "b", ";", "c", `ls\;`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JoinCommands() = %v, want %v", got, want)
+	}
+	if got := JoinCommands(nil); len(got) != 0 {
+		t.Errorf("JoinCommands(nil) = %v, want none", got)
+	}
+}
+
+// TestSendTextCommands_Tmux types into a pane on a private tmux server in one invocation
+func TestSendTextCommands_Tmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := filepath.Join(t.TempDir(), "tmux.sock")
+	tmuxCmd := func(args ...string) string {
+		t.Helper()
+		output, err := exec.Command("tmux", append([]string{"-S", socket, "-f", "/dev/null"}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("tmux %v failed: %v\n%s", args, err, output)
+		}
+		return string(output)
+	}
+	t.Cleanup(func() { _ = exec.Command("tmux", "-S", socket, "kill-server").Run() })
+
+	tmuxCmd("new-session", "-d", "-s", "dev", "cat")
+	text := `-N 2 Enter C-c ; a\; done;`
+	tmuxCmd(JoinCommands(SendTextCommands("dev", text))...)
+
+	for range 20 {
+		if strings.Contains(tmuxCmd("capture-pane", "-p", "-t", "dev:"), text+"\n"+text) {
+			return // Typed literally, then echoed by cat after Enter
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Errorf("pane = %q, want the text typed and echoed", tmuxCmd("capture-pane", "-p", "-t", "dev:"))
+}
+
+func TestAddWindows(t *testing.T) {
+	sessions := []Session{{Name: "main"}, {Name: "tests"}}
+	output := []string{
+		"main\t0\t0\tclaude",
+		"main\t1\t1\tshell\twith tab",
+		"tests\t2\t1\twatch",
+		"gone\t0\t1\tbash", // Session ended between the two listings
+		"malformed",
+	}
+
+	got := AddWindows(sessions, output)
+	want := []Session{
+		{Name: "main", Windows: []Window{{Index: 0, Name: "claude"}, {Index: 1, Name: "shell\twith tab", Active: true}}},
+		{Name: "tests", Windows: []Window{{Index: 2, Name: "watch", Active: true}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AddWindows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWindow_FormatWindow(t *testing.T) {
+	if got := (Window{Index: 1, Name: "shell", Active: true}).FormatWindow(); got != "1: shell*" {
+		t.Errorf("FormatWindow() = %q, want %q", got, "1: shell*")
+	}
+	if got := (Window{Index: 0, Name: "claude"}).FormatWindow(); got != "0: claude" {
+		t.Errorf("FormatWindow() = %q, want %q", got, "0: claude")
+	}
+}
+
+func TestAttachArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		observe    bool
+		ignoreSize bool
+		want       []string
+	}{
+		{"attach", false, true, []string{"attach-session", "-t", "main:1"}},
+		{"observe", true, false, []string{"attach-session", "-r", "-t", "main:1"}},
+		{"observe ignoring size", true, true, []string{"attach-session", "-f", "read-only,ignore-size", "-t", "main:1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AttachArgs("main:1", tt.observe, tt.ignoreSize); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AttachArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
 	"strconv"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -14,6 +15,9 @@ import (
 	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/hosttmux"
+	"github.com/christophergyman/claude-quick/internal/notify"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 	"github.com/christophergyman/claude-quick/internal/util"
 )
 
@@ -21,9 +25,36 @@ import (
 var (
 	errNoInstanceSelected = errors.New("no container instance selected")
 	errNoSessionSelected  = errors.New("no tmux session selected")
+	errNoWindowSelected   = errors.New("no tmux window selected")
 	errNoWorktreeSelected = errors.New("no worktree selected")
 )
 
+// worktreeDeleteMode selects what happens to a worktree's work and branch on deletion
+type worktreeDeleteMode int
+
+const (
+	// deleteWorktreeOnly removes the worktree directory and keeps the branch
+	deleteWorktreeOnly worktreeDeleteMode = iota
+	// deleteWorktreeAndBranches removes the worktree plus its local and remote branch
+	deleteWorktreeAndBranches
+	// deleteWorktreeAfterStash stashes uncommitted changes before removing the worktree
+	deleteWorktreeAfterStash
+	// deleteWorktreeAfterPush commits and pushes all work before removing the worktree
+	deleteWorktreeAfterPush
+)
+
+// tmuxNameAction selects what the name typed in StateTmuxNameInput is used for
+type tmuxNameAction int
+
+const (
+	// renameSession renames the selected session
+	renameSession tmuxNameAction = iota
+	// renameWindow renames the selected window
+	renameWindow
+	// newWindow creates a window in the selected session (empty name lets tmux pick)
+	newWindow
+)
+
 // discoverInstances returns a command that discovers devcontainer instances
 func (m Model) discoverInstances() tea.Cmd {
 	return func() tea.Msg {
@@ -32,7 +63,10 @@ func (m Model) discoverInstances() tea.Cmd {
 			m.config.MaxDepth,
 			m.config.ExcludedDirs,
 		)
-		return instancesDiscoveredMsg{instances: instances}
+		return instancesDiscoveredMsg{
+			instances: instances,
+			warning:   joinWarnings(devcontainer.LegacyCredentialFileWarnings(instances)...),
+		}
 	}
 }
 
@@ -44,6 +78,28 @@ func (m Model) refreshInstanceStatus() tea.Cmd {
 	}
 }
 
+// writeCredentials resolves the selected instance's credentials and writes them
+// for its container, returning a warning if any could not be resolved or written
+func (m Model) writeCredentials() string {
+	if m.config == nil {
+		return ""
+	}
+	var authWarning string
+	result := m.config.Auth.Resolve(m.selectedInstance.Name)
+	if len(result.Credentials) > 0 {
+		// Write credentials to the private credential directory, outside the project
+		if err := auth.WriteCredentialFile(m.selectedInstance.Path, result.Credentials); err != nil {
+			authWarning = fmt.Sprintf("failed to write credentials: %v", err)
+		}
+		// Older versions wrote credentials into the project, make sure git never picks them up
+		authWarning = joinWarnings(authWarning, devcontainer.GuardCredentialFile(m.selectedInstance.Path))
+	}
+	if result.HasErrors() {
+		authWarning = joinWarnings(authWarning, result.ErrorSummary())
+	}
+	return authWarning
+}
+
 // startContainer returns a command that starts the devcontainer
 func (m Model) startContainer() tea.Cmd {
 	return func() tea.Msg {
@@ -57,22 +113,7 @@ func (m Model) startContainer() tea.Cmd {
 		}
 
 		// Resolve and write authentication credentials
-		var authWarning string
-		if m.config != nil {
-			result := m.config.Auth.Resolve(m.selectedInstance.Name)
-			if len(result.Credentials) > 0 {
-				// Write credentials to file in project directory
-				if err := auth.WriteCredentialFile(m.selectedInstance.Path, result.Credentials); err != nil {
-					authWarning = fmt.Sprintf("failed to write credentials: %v", err)
-				}
-			}
-			if result.HasErrors() {
-				if authWarning != "" {
-					authWarning += "; "
-				}
-				authWarning += result.ErrorSummary()
-			}
-		}
+		authWarning := m.writeCredentials()
 
 		// Start the container (path-based, each worktree has unique path)
 		if err := devcontainer.Up(m.selectedInstance.Path); err != nil {
@@ -94,12 +135,13 @@ func (m Model) stopContainer() tea.Cmd {
 		if m.selectedInstance == nil {
 			return containerErrorMsg{err: errNoInstanceSelected}
 		}
+		warning := joinWarnings(m.archiveScrollback(), m.saveSessions())
 		if err := devcontainer.Stop(m.selectedInstance.Path); err != nil {
 			return containerErrorMsg{err: err}
 		}
 		// Clean up credential file after stopping container
 		auth.CleanupCredentialFile(m.selectedInstance.Path)
-		return containerStoppedMsg{}
+		return containerStoppedMsg{warning: warning}
 	}
 }
 
@@ -109,10 +151,110 @@ func (m Model) restartContainer() tea.Cmd {
 		if m.selectedInstance == nil {
 			return containerErrorMsg{err: errNoInstanceSelected}
 		}
+		warning := joinWarnings(m.archiveScrollback(), m.saveSessions())
 		if err := devcontainer.Restart(m.selectedInstance.Path); err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return containerRestartedMsg{}
+		// The container's stop removed its credentials, write them again
+		warning = joinWarnings(warning, m.writeCredentials())
+		return containerRestartedMsg{warning: warning}
+	}
+}
+
+// saveSessions records the layout of the selected instance's sessions so they can
+// be restored once the container is back, returning a warning if that fails
+func (m Model) saveSessions() string {
+	if _, err := devcontainer.SaveTmuxSessions(m.selectedInstance.Path, util.ExpandPath(constants.SessionStateDir)); err != nil {
+		return "Sessions not saved: " + err.Error()
+	}
+	return ""
+}
+
+// restoreSessions returns a command that recreates the saved sessions, relaunching
+// the agent with --continue if resume is set, then discards the saved layout
+func (m Model) restoreSessions(resume bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if m.savedSessions == nil {
+			return sessionsRestoredMsg{}
+		}
+		style, err := m.config.TmuxStyle(m.darkMode)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		// Panes that ran the agent start it again, even if it was launched by hand
+		launchCmd := m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, m.config.LaunchCommand)
+		if launchCmd == "" {
+			launchCmd = tmux.AgentCommand
+		}
+		if resume {
+			launchCmd += " " + constants.ContinueFlag
+		}
+
+		var errs []string
+		for _, err := range devcontainer.RestoreTmuxSessions(m.selectedInstance.Path, m.savedSessions.Sessions, launchCmd, style) {
+			errs = append(errs, err.Error())
+		}
+		if err := devcontainer.DiscardSavedSessions(m.selectedInstance.Path, util.ExpandPath(constants.SessionStateDir)); err != nil {
+			errs = append(errs, err.Error())
+		}
+		return sessionsRestoredMsg{errors: errs}
+	}
+}
+
+// discardSavedSessions returns a command that forgets the saved sessions and shows the picker
+func (m Model) discardSavedSessions() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if err := devcontainer.DiscardSavedSessions(m.selectedInstance.Path, util.ExpandPath(constants.SessionStateDir)); err != nil {
+			return sessionsRestoredMsg{errors: []string{err.Error()}}
+		}
+		return sessionsRestoredMsg{}
+	}
+}
+
+// archiveScrollback exports the scrollback of every session of the selected instance
+// if archiving is enabled, returning a warning if that fails. Stopping a container
+// goes ahead regardless, as it may be unhealthy.
+func (m Model) archiveScrollback() string {
+	dir := m.archiveDir()
+	if dir == "" {
+		return ""
+	}
+	if _, err := devcontainer.ExportScrollback(m.selectedInstance.Path, "", dir); err != nil {
+		return "Scrollback not archived: " + err.Error()
+	}
+	return ""
+}
+
+// archiveDir returns the directory the selected instance's scrollback is archived to,
+// or "" if archiving is off
+func (m Model) archiveDir() string {
+	if m.config == nil || m.selectedInstance == nil {
+		return ""
+	}
+	return m.config.ArchiveDirFor(m.selectedInstance.Path)
+}
+
+// exportScrollback returns a command that writes the selected session's scrollback to the host
+func (m Model) exportScrollback() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if m.selectedSession == nil {
+			return containerErrorMsg{err: errNoSessionSelected}
+		}
+		dir := m.config.TranscriptDirFor(m.selectedInstance.Path)
+		paths, err := devcontainer.ExportScrollback(m.selectedInstance.Path, m.selectedSession.Name, dir)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return scrollbackExportedMsg{paths: paths}
 	}
 }
 
@@ -125,7 +267,13 @@ func (m Model) stopTmuxSession() tea.Cmd {
 		if m.selectedSession == nil {
 			return containerErrorMsg{err: errNoSessionSelected}
 		}
-		if err := devcontainer.KillTmuxSession(m.selectedInstance.Path, m.selectedSession.Name); err != nil {
+		if m.selectedWindow != nil {
+			if err := devcontainer.KillTmuxWindow(m.selectedInstance.Path, m.selectedSession.Name, m.selectedWindow.Index); err != nil {
+				return containerErrorMsg{err: err}
+			}
+			return tmuxSessionStoppedMsg{}
+		}
+		if err := devcontainer.KillTmuxSession(m.selectedInstance.Path, m.selectedSession.Name, m.archiveDir()); err != nil {
 			return containerErrorMsg{err: err}
 		}
 		return tmuxSessionStoppedMsg{}
@@ -142,14 +290,20 @@ func (m Model) restartTmuxSession() tea.Cmd {
 			return containerErrorMsg{err: errNoSessionSelected}
 		}
 		sessionName := m.selectedSession.Name
+		// Resolve launch command (project-specific or global default)
+		launchCmd := m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, m.config.LaunchCommand)
+		// Rebuild the layout of the template the session was created from
+		tmpl := m.config.TmuxTemplate(devcontainer.TmuxSessionTemplate(m.selectedInstance.Path, sessionName))
+		style, err := m.config.TmuxStyle(m.darkMode)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
 		// Kill existing session
-		if err := devcontainer.KillTmuxSession(m.selectedInstance.Path, sessionName); err != nil {
+		if err := devcontainer.KillTmuxSession(m.selectedInstance.Path, sessionName, m.archiveDir()); err != nil {
 			return containerErrorMsg{err: err}
 		}
-		// Resolve launch command (project-specific or global default)
-		launchCmd := m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, m.config.LaunchCommand)
 		// Create new session with same name
-		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, sessionName, launchCmd); err != nil {
+		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, sessionName, launchCmd, tmpl, style); err != nil {
 			return containerErrorMsg{err: err}
 		}
 		return tmuxSessionRestartedMsg{}
@@ -173,7 +327,220 @@ func (m Model) loadTmuxSessions() tea.Cmd {
 		if err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return tmuxSessionsLoadedMsg{sessions: sessions}
+		windows, err := devcontainer.ListTmuxWindows(m.selectedInstance.Path)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		msg := tmuxSessionsLoadedMsg{sessions: sessions, windows: windows}
+		if len(sessions) == 0 {
+			// An unreadable saved layout just means nothing is offered for restore
+			msg.saved, _ = devcontainer.LoadSavedSessions(m.selectedInstance.Path, util.ExpandPath(constants.SessionStateDir))
+		}
+		return msg
+	}
+}
+
+// applyTmuxName returns a command that renames the selected session or window, or
+// creates a new window, depending on tmuxNameAction
+func (m Model) applyTmuxName(name string) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if m.selectedSession == nil {
+			return containerErrorMsg{err: errNoSessionSelected}
+		}
+		path, session := m.selectedInstance.Path, m.selectedSession.Name
+		var err error
+		switch m.tmuxNameAction {
+		case renameSession:
+			err = devcontainer.RenameTmuxSession(path, session, name)
+		case renameWindow:
+			if m.selectedWindow == nil {
+				return containerErrorMsg{err: errNoWindowSelected}
+			}
+			err = devcontainer.RenameTmuxWindow(path, session, m.selectedWindow.Index, name)
+		case newWindow:
+			err = devcontainer.NewTmuxWindow(path, session, name)
+		}
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return tmuxWindowsChangedMsg{}
+	}
+}
+
+// moveTmuxWindow returns a command that moves the selected window to another session
+func (m Model) moveTmuxWindow(destSession string) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if m.selectedSession == nil || m.selectedWindow == nil {
+			return containerErrorMsg{err: errNoWindowSelected}
+		}
+		if err := devcontainer.MoveTmuxWindow(m.selectedInstance.Path, m.selectedSession.Name, m.selectedWindow.Index, destSession); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return tmuxWindowsChangedMsg{}
+	}
+}
+
+// capturePanes returns a command that captures the active pane of every session
+func (m Model) capturePanes() tea.Cmd {
+	if m.selectedInstance == nil {
+		return nil
+	}
+	path := m.selectedInstance.Path
+	return func() tea.Msg {
+		// A failed capture just leaves previews empty and agent states unknown
+		panes, _ := devcontainer.CaptureSessionPanes(path, constants.PanePreviewLines)
+		return panesCapturedMsg{panes: panes}
+	}
+}
+
+// schedulePanePreview returns a command that triggers the next pane preview refresh
+func schedulePanePreview(gen int) tea.Cmd {
+	return tea.Tick(constants.PanePreviewInterval, func(time.Time) tea.Msg {
+		return panePreviewTickMsg{gen: gen}
+	})
+}
+
+// sendTarget is a tmux session that text can be typed into
+type sendTarget struct {
+	instance devcontainer.ContainerInstance
+	session  string
+	state    tmux.AgentState // Agent state when the targets were listed
+}
+
+// label returns the display name of the target
+func (t sendTarget) label() string {
+	return t.instance.DisplayName() + " / " + t.session
+}
+
+// broadcastTargets lists the sessions of all running instances, sorted by session name
+// within each instance
+func broadcastTargets(statuses []devcontainer.ContainerInstanceWithStatus) []sendTarget {
+	var targets []sendTarget
+	for _, status := range statuses {
+		sessions := make([]string, 0, len(status.AgentStates))
+		for session := range status.AgentStates {
+			sessions = append(sessions, session)
+		}
+		sort.Strings(sessions)
+		for _, session := range sessions {
+			targets = append(targets, sendTarget{
+				instance: status.ContainerInstance,
+				session:  session,
+				state:    status.AgentStates[session],
+			})
+		}
+	}
+	return targets
+}
+
+// loadBroadcastTargets returns a command that lists the sessions of all instances
+func (m Model) loadBroadcastTargets() tea.Cmd {
+	return func() tea.Msg {
+		return broadcastTargetsLoadedMsg{statuses: devcontainer.GetAllInstancesStatus(m.instances)}
+	}
+}
+
+// sendKeys returns a command that types the input text into the selected targets,
+// with one exec per container
+func (m Model) sendKeys() tea.Cmd {
+	return func() tea.Msg {
+		text := m.sendInput.Value()
+		var paths []string
+		sessions := make(map[string][]string)
+		for i, target := range m.sendTargets {
+			if !m.sendSelected[i] {
+				continue
+			}
+			path := target.instance.Path
+			if _, ok := sessions[path]; !ok {
+				paths = append(paths, path)
+			}
+			sessions[path] = append(sessions[path], target.session)
+		}
+
+		var result keysSentMsg
+		for _, path := range paths {
+			if err := devcontainer.SendKeys(path, sessions[path], text); err != nil {
+				result.errors = append(result.errors, err.Error())
+				continue
+			}
+			result.sent += len(sessions[path])
+		}
+		return result
+	}
+}
+
+// sweepCredentials removes credential files left behind by containers that are
+// no longer running (after a crash, or containers stopped outside claude-quick)
+func (m Model) sweepCredentials() tea.Cmd {
+	return func() tea.Msg {
+		_, _ = devcontainer.SweepCredentials()
+		return nil
+	}
+}
+
+// watchContainerExits starts following docker for stopped devcontainers
+func (m Model) watchContainerExits() tea.Cmd {
+	if m.ctx == nil {
+		return nil // Not tied to a program that can stop the watcher
+	}
+	return func() tea.Msg {
+		exits, err := devcontainer.WatchContainerExits(m.ctx)
+		if err != nil {
+			return nil // Credentials are still swept on the next start
+		}
+		return containerExitsWatchedMsg{exits: exits}
+	}
+}
+
+// waitForContainerExit returns a command that waits for the next stopped devcontainer
+func waitForContainerExit(exits <-chan devcontainer.ContainerExit) tea.Cmd {
+	return func() tea.Msg {
+		exit, ok := <-exits
+		if !ok {
+			return nil // docker events exited
+		}
+		return containerExitedMsg{exit: exit, exits: exits}
+	}
+}
+
+// cleanupExitedCredentials removes the credential file of a stopped container
+func cleanupExitedCredentials(exit devcontainer.ContainerExit) tea.Cmd {
+	return func() tea.Msg {
+		_ = devcontainer.CleanupExitedCredentials(exit) // Otherwise swept on the next start
+		return nil
+	}
+}
+
+// scheduleAgentPoll returns a command that triggers the next background agent poll
+func scheduleAgentPoll() tea.Cmd {
+	return tea.Tick(constants.AgentPollInterval, func(time.Time) tea.Msg {
+		return agentPollTickMsg{}
+	})
+}
+
+// pollAgents returns a command that inspects the status and agents of all instances
+func (m Model) pollAgents() tea.Cmd {
+	return func() tea.Msg {
+		return agentsPolledMsg{statuses: devcontainer.GetAllInstancesStatus(m.instances)}
+	}
+}
+
+// sendNotification returns a command that alerts the user that agents need attention
+func sendNotification(method, message string) tea.Cmd {
+	return func() tea.Msg {
+		// Best effort: the highlighted dashboard row remains if delivery fails
+		term := &rendererSafeWriter{out: os.Stdout}
+		if err := notify.Send(term, method, constants.NotifyTitle, message); err == nil {
+			_ = term.Flush()
+		}
+		return nil
 	}
 }
 
@@ -185,27 +552,49 @@ func (m Model) createTmuxSession(name string) tea.Cmd {
 		}
 		// Resolve launch command (project-specific or global default)
 		launchCmd := m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, m.config.LaunchCommand)
-		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, name, launchCmd); err != nil {
+		tmpl := m.config.TmuxTemplate(m.sessionTemplate)
+		style, err := m.config.TmuxStyle(m.darkMode)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, name, launchCmd, tmpl, style); err != nil {
 			return containerErrorMsg{err: err}
 		}
 		return tmuxSessionCreatedMsg{}
 	}
 }
 
-// attachToSession attaches to a tmux session using tea.ExecProcess
+// attachToSession attaches to a tmux session (or "session:window" target) using tea.ExecProcess
 // This suspends the TUI, runs tmux as a subprocess, and returns to TUI on detach
 func (m Model) attachToSession(sessionName string) (tea.Model, tea.Cmd) {
+	return m.attach(sessionName, false)
+}
+
+// observeSession attaches to a tmux session read-only, so watching an agent
+// cannot type into it (or, by default, resize its windows)
+func (m Model) observeSession(sessionName string) (tea.Model, tea.Cmd) {
+	return m.attach(sessionName, true)
+}
+
+// attach runs tmux attach for a target, read-only when observing
+func (m Model) attach(target string, observe bool) (tea.Model, tea.Cmd) {
 	if m.selectedInstance == nil {
 		m.state = StateError
 		m.err = errNoInstanceSelected
 		return m, nil
 	}
 	m.state = StateAttaching
+	m.observing = observe
+
+	ignoreSize := m.config != nil && m.config.IsObserveIgnoreSize()
+	if m.config != nil && hosttmux.Active(m.config.HostTmux) {
+		return m, m.openInHostTmux(target, observe, ignoreSize)
+	}
 
-	// Build the command to attach to tmux (path-based)
-	c := exec.Command("devcontainer", "exec",
-		"--workspace-folder", m.selectedInstance.Path,
-		"tmux", "attach", "-t", sessionName)
+	// Build the command to attach to tmux (docker exec when the container's
+	// metadata resolves, otherwise devcontainer exec)
+	args := append([]string{"tmux"}, tmux.AttachArgs(target, observe, ignoreSize)...)
+	c := devcontainer.InteractiveCommand(m.selectedInstance.Path, args...)
 
 	// Use tea.ExecProcess to run tmux and return to TUI when done
 	return m, tea.ExecProcess(c, func(err error) tea.Msg {
@@ -214,21 +603,241 @@ func (m Model) attachToSession(sessionName string) (tea.Model, tea.Cmd) {
 	})
 }
 
-// deleteWorktree removes the selected git worktree
+// openInHostTmux runs the attach in a host tmux window or pane of its own (or
+// switches to the one already open for the target), leaving claude-quick
+// running as a launcher
+func (m Model) openInHostTmux(target string, observe, ignoreSize bool) tea.Cmd {
+	inst := *m.selectedInstance
+	mode := m.config.HostTmux
+	return func() tea.Msg {
+		name := inst.DisplayName() + "/" + target
+		if observe {
+			name += " (observe)"
+		}
+		args := append([]string{"tmux"}, tmux.AttachArgs(target, observe, ignoreSize)...)
+		c := devcontainer.InteractiveCommand(inst.Path, args...)
+		argv := append([]string{c.Path}, c.Args[1:]...)
+		if err := hosttmux.Open(mode, name, argv); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return hostTmuxOpenedMsg{}
+	}
+}
+
+// loadWorktreeStatus checks the selected worktree for uncommitted, unpushed and unmerged work
+func (m Model) loadWorktreeStatus() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+			return containerErrorMsg{err: errNoWorktreeSelected}
+		}
+		status, err := devcontainer.GetWorktreeStatus(*m.selectedInstance.Worktree)
+		return worktreeStatusLoadedMsg{status: status, err: err}
+	}
+}
+
+// deleteWorktree removes the selected git worktree using the chosen delete mode
 func (m Model) deleteWorktree() tea.Cmd {
 	return func() tea.Msg {
 		if m.selectedInstance == nil {
 			return containerErrorMsg{err: errNoWorktreeSelected}
 		}
 		// Pass main repo path to handle cases where worktree directory was deleted externally
-		var mainRepoPath string
+		var mainRepoPath, branch string
 		if m.selectedInstance.Worktree != nil {
 			mainRepoPath = m.selectedInstance.Worktree.MainRepo
+			branch = m.selectedInstance.Worktree.Branch
 		}
+
+		// Save work before the directory disappears
+		switch m.worktreeDeleteMode {
+		case deleteWorktreeAfterStash:
+			if err := devcontainer.StashWorktreeChanges(m.selectedInstance.Path, constants.WorktreeStashMessage); err != nil {
+				return containerErrorMsg{err: err}
+			}
+		case deleteWorktreeAfterPush:
+			if err := devcontainer.CommitAndPushWorktree(m.selectedInstance.Path, branch, constants.WorktreeCommitMessage); err != nil {
+				return containerErrorMsg{err: err}
+			}
+		}
+
 		if err := devcontainer.RemoveWorktree(m.selectedInstance.Path, mainRepoPath); err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return worktreeDeletedMsg{}
+
+		// Branch can only be deleted once no worktree has it checked out
+		var warning string
+		if m.worktreeDeleteMode == deleteWorktreeAndBranches && branch != "" && mainRepoPath != "" {
+			w, err := devcontainer.DeleteBranch(mainRepoPath, branch, true)
+			if err != nil {
+				warning = fmt.Sprintf("Worktree removed but %v", err)
+			} else if w != "" {
+				warning = "Worktree removed but " + w
+			}
+		}
+		return worktreeDeletedMsg{warning: warning}
+	}
+}
+
+// toggleWorktreeLock locks or unlocks the selected worktree
+func (m Model) toggleWorktreeLock() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		wt := *m.selectedInstance.Worktree
+		if wt.Locked {
+			if err := devcontainer.UnlockWorktree(wt); err != nil {
+				return containerErrorMsg{err: err}
+			}
+			return worktreeLockChangedMsg{locked: false}
+		}
+		if err := devcontainer.LockWorktree(wt, constants.WorktreeLockReason); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return worktreeLockChangedMsg{locked: true}
+	}
+}
+
+// renameWorktree renames the selected worktree's branch and moves its directory
+func (m Model) renameWorktree(newBranch string) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		newPath, wasRunning, err := devcontainer.RenameWorktree(*m.selectedInstance.Worktree, newBranch, m.worktreeConfig(),
+			util.ExpandPath(constants.SessionStateDir), m.config.TranscriptRoot())
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return worktreeRenamedMsg{worktreePath: newPath, restart: wasRunning}
+	}
+}
+
+// integrateWorktree rebases or merges the selected worktree's branch with its base
+func (m Model) integrateWorktree() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		wt := *m.selectedInstance.Worktree
+
+		// A previous integration is stopped on conflicts - show it so it can be aborted
+		if devcontainer.IntegrationInProgress(wt) {
+			return integrateDoneMsg{result: devcontainer.IntegrateResult{
+				Branch:    devcontainer.IntegratingBranch(wt),
+				Base:      devcontainer.BaseBranch(wt),
+				Conflicts: devcontainer.ConflictedFiles(wt),
+			}}
+		}
+
+		result, err := devcontainer.IntegrateWorktree(wt, m.integrateMode, m.integrateFastForward)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return integrateDoneMsg{result: result}
+	}
+}
+
+// abortIntegration aborts the stopped rebase/merge in the selected worktree
+func (m Model) abortIntegration() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if err := devcontainer.AbortIntegration(*m.selectedInstance.Worktree); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return integrateAbortedMsg{}
+	}
+}
+
+// scanCleanupCandidates finds worktrees whose branch is merged, whose PR is merged
+// or whose GitHub issue is closed
+func (m Model) scanCleanupCandidates() tea.Cmd {
+	return func() tea.Msg {
+		candidates := devcontainer.FindMergedWorktrees(m.instances)
+		found := make(map[string]bool, len(candidates))
+		for _, c := range candidates {
+			found[c.Instance.Path] = true
+		}
+
+		// GitHub checks are optional - skip them if gh is unavailable
+		var warning string
+		if err := github.CheckCLI(); err != nil {
+			warning = fmt.Sprintf("GitHub checks skipped: %v", err)
+		} else {
+			candidates = append(candidates, m.findFinishedGitHubWorktrees(found)...)
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].LastActivity.Before(candidates[j].LastActivity)
+		})
+		return cleanupCandidatesLoadedMsg{candidates: candidates, warning: warning}
+	}
+}
+
+// findFinishedGitHubWorktrees returns worktrees (not already in skip) whose
+// pull request is merged or whose linked issue is closed
+func (m Model) findFinishedGitHubWorktrees(skip map[string]bool) []devcontainer.CleanupCandidate {
+	var candidates []devcontainer.CleanupCandidate
+
+	type repoInfo struct {
+		owner, repo string
+		merged      map[string]bool
+		ok          bool
+	}
+	repos := make(map[string]*repoInfo) // Cache GitHub lookups per main repo
+
+	for _, inst := range m.instances {
+		if inst.Worktree == nil || inst.Worktree.IsMain || inst.Worktree.Locked || skip[inst.Path] {
+			continue
+		}
+
+		mainRepo := inst.Worktree.MainRepo
+		info, seen := repos[mainRepo]
+		if !seen {
+			info = &repoInfo{}
+			repos[mainRepo] = info
+			owner, repo, err := github.DetectRepository(mainRepo)
+			if err == nil {
+				info.owner, info.repo, info.ok = owner, repo, true
+				info.merged, _ = github.FetchMergedPRBranches(owner, repo, constants.DefaultMergedPRLimit)
+			}
+		}
+		if !info.ok {
+			continue
+		}
+
+		branch := inst.Worktree.Branch
+		if info.merged[branch] {
+			candidates = append(candidates, devcontainer.NewCleanupCandidate(inst, "PR merged"))
+			continue
+		}
+		if number, ok := github.ParseIssueNumber(branch, m.config.GitHub.BranchPrefix); ok {
+			state, err := github.FetchIssueState(info.owner, info.repo, number)
+			if err == nil && state == github.IssueStateClosed {
+				candidates = append(candidates, devcontainer.NewCleanupCandidate(inst, fmt.Sprintf("issue #%d closed", number)))
+			}
+		}
+	}
+	return candidates
+}
+
+// cleanupWorktrees removes the selected cleanup candidates
+func (m Model) cleanupWorktrees() tea.Cmd {
+	return func() tea.Msg {
+		var result cleanupDoneMsg
+		for i, c := range m.cleanupCandidates {
+			if !m.cleanupSelected[i] {
+				continue
+			}
+			if err := devcontainer.CleanupWorktree(c); err != nil {
+				result.errors = append(result.errors, err.Error())
+				continue
+			}
+			result.removed++
+		}
+		return result
 	}
 }
 
@@ -238,18 +847,29 @@ func (m Model) createWorktree(branchName string) tea.Cmd {
 		if m.selectedInstance == nil {
 			return containerErrorMsg{err: errNoInstanceSelected}
 		}
-		worktreePath, pushWarning, err := devcontainer.CreateWorktree(
+		worktreePath, createWarning, err := devcontainer.CreateWorktree(
 			m.selectedInstance.Path,
 			branchName,
 			m.config.IsAutoPushWorktree(),
+			m.worktreeConfig(),
 		)
 		if err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return worktreeCreatedMsg{worktreePath: worktreePath, pushWarning: pushWarning}
+		return worktreeCreatedMsg{
+			worktreePath:     worktreePath,
+			createWarning:    createWarning,
+			bootstrapWarning: m.bootstrapWorktree(worktreePath, branchName),
+		}
 	}
 }
 
+// bootstrapWorktree copies configured files into a new worktree and runs its
+// post-create commands, returning any problems as a single warning
+func (m Model) bootstrapWorktree(worktreePath, branchName string) string {
+	return joinWarnings(devcontainer.BootstrapWorktree(worktreePath, branchName, m.worktreeConfig())...)
+}
+
 // loadGitHubIssues fetches issues from the current repository
 func (m Model) loadGitHubIssues() tea.Cmd {
 	return func() tea.Msg {
@@ -308,20 +928,24 @@ func (m Model) createWorktreeFromIssue() tea.Cmd {
 		branchName := github.GenerateBranchName(m.selectedIssue, m.config.GitHub.BranchPrefix)
 
 		// Validate branch name (reuse existing validation)
-		if err := devcontainer.ValidateBranchName(branchName); err != nil {
+		if err := devcontainer.ValidateBranchName(branchName, m.worktreeConfig().ReservedBranchPatterns()); err != nil {
 			return containerErrorMsg{err: err}
 		}
 
 		// Create worktree
-		worktreePath, pushWarning, err := devcontainer.CreateWorktree(
+		worktreePath, createWarning, err := devcontainer.CreateWorktree(
 			m.selectedInstance.Path,
 			branchName,
 			m.config.IsAutoPushWorktree(),
+			m.worktreeConfig(),
 		)
 		if err != nil {
 			return containerErrorMsg{err: err}
 		}
 
+		// Bring over untracked files before the container is auto-started
+		bootstrapWarning := m.bootstrapWorktree(worktreePath, branchName)
+
 		// Add "in-progress" label if enabled
 		var labelWarning string
 		if m.config.GitHub.IsAutoLabelEnabled() {
@@ -343,10 +967,11 @@ func (m Model) createWorktreeFromIssue() tea.Cmd {
 		}
 
 		return githubWorktreeCreatedMsg{
-			worktreePath: worktreePath,
-			branchName:   branchName,
-			pushWarning:  pushWarning,
-			labelWarning: labelWarning,
+			worktreePath:     worktreePath,
+			branchName:       branchName,
+			createWarning:    createWarning,
+			labelWarning:     labelWarning,
+			bootstrapWarning: bootstrapWarning,
 		}
 	}
 }
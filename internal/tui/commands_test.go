@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+func TestBroadcastTargets(t *testing.T) {
+	app := devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}}
+	docs := devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "docs", Path: "/src/docs"}}
+	statuses := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: app, AgentStates: map[string]tmux.AgentState{"tests": tmux.AgentWorking, "main": tmux.AgentWaiting}},
+		{ContainerInstance: docs, Status: devcontainer.StatusStopped},
+		{ContainerInstance: docs, AgentStates: map[string]tmux.AgentState{"main": tmux.AgentIdle}},
+	}
+
+	var got []string
+	for _, target := range broadcastTargets(statuses) {
+		got = append(got, target.label()+"="+target.state.String())
+	}
+	want := []string{"app / main=waiting", "app / tests=working", "docs / main=idle"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("broadcastTargets() = %v, want %v", got, want)
+	}
+}
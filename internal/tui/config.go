@@ -50,6 +50,14 @@ func RenderConfigDisplay(cfg *config.Config) string {
 	b.WriteString(fmt.Sprintf("%ds", cfg.ContainerTimeout))
 	b.WriteString("\n\n")
 
+	// Transcripts
+	b.WriteString(ColumnHeaderStyle.Render("Transcripts: "))
+	b.WriteString(cfg.TranscriptRoot())
+	if cfg.ArchiveTranscripts {
+		b.WriteString(DimmedStyle.Render(" (archived on session kill and container stop)"))
+	}
+	b.WriteString("\n\n")
+
 	// Footer
 	b.WriteString("  " + RenderSeparator(defaultWidth-4))
 	b.WriteString("\n")
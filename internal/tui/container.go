@@ -3,11 +3,13 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/christophergyman/claude-quick/internal/config"
 	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 const defaultWidth = 65
@@ -116,8 +118,9 @@ func RenderRefreshingStatus(spinnerView string) string {
 	return renderSpinnerAction(spinnerView, "Refreshing container status", "")
 }
 
-// RenderDashboard renders the container dashboard with status indicators
-func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, cursor int, width int, warning string) string {
+// RenderDashboard renders the container dashboard with status indicators.
+// Instances in attention are highlighted until connected to.
+func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, cursor int, width int, warning string, attention map[string]bool) string {
 	if width <= 0 {
 		width = defaultWidth
 	}
@@ -171,11 +174,17 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 			sessionInfo = fmt.Sprintf(" [%d]", instance.SessionCount)
 		}
 
+		// Most urgent agent state across the container's sessions
+		agentInfo := ""
+		if state, count := mostUrgentAgent(instance.AgentStates); state != tmux.AgentUnknown {
+			agentInfo = " " + agentBadge(state, count)
+		}
+
 		// Project name
-		displayName := instance.DisplayName() + sessionInfo
+		displayName := instance.DisplayName() + worktreeBadges(instance.Worktree) + sessionInfo
 
 		// Calculate spacing for right alignment
-		nameWidth := lipgloss.Width(displayName)
+		nameWidth := lipgloss.Width(displayName) + lipgloss.Width(agentInfo)
 		spacing := width - 4 - nameWidth - statusWidth
 		if spacing < 1 {
 			spacing = 1
@@ -183,10 +192,13 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 
 		// Render project line
 		var line string
-		if i == cursor {
-			line = Cursor() + SelectedStyle.Render(displayName) + repeatChar(" ", spacing) + statusText
-		} else {
-			line = NoCursor() + ItemStyle.Render(displayName) + repeatChar(" ", spacing) + statusText
+		switch {
+		case i == cursor:
+			line = Cursor() + SelectedStyle.Render(displayName) + agentInfo + repeatChar(" ", spacing) + statusText
+		case attention[instance.Path]:
+			line = NoCursor() + AttentionStyle.Render(displayName) + agentInfo + repeatChar(" ", spacing) + statusText
+		default:
+			line = NoCursor() + ItemStyle.Render(displayName) + agentInfo + repeatChar(" ", spacing) + statusText
 		}
 		b.WriteString(line)
 		b.WriteString("\n")
@@ -207,22 +219,33 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 	b.WriteString("  " + RenderSeparator(width-4))
 	b.WriteString("\n")
 
-	// Key bindings - first row
+	// Key bindings - first row (containers)
 	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s  %s  %s",
 		RenderKeyBinding("↑↓", "navigate"),
 		RenderKeyBinding("enter", "connect"),
-		RenderKeyBinding("n", "new"),
-		RenderKeyBinding("d", "delete"),
 		RenderKeyBinding("x", "stop"),
 		RenderKeyBinding("r", "restart"),
+		RenderKeyBinding("R", "refresh"),
+		RenderKeyBinding("b", "broadcast"),
 	)
 	b.WriteString(keybindings1)
 	b.WriteString("\n")
 
-	// Key bindings - second row with right-aligned detach hint
-	leftKeys := fmt.Sprintf("  %s  %s  %s  %s  %s  %s",
+	// Key bindings - second row (worktrees)
+	keybindings2 := fmt.Sprintf("  %s  %s  %s  %s  %s  %s",
+		RenderKeyBinding("n", "new"),
+		RenderKeyBinding("d", "delete"),
+		RenderKeyBinding("i", "integrate"),
+		RenderKeyBinding("m", "rename"),
+		RenderKeyBinding("l", "lock"),
+		RenderKeyBinding("c", "cleanup"),
+	)
+	b.WriteString(keybindings2)
+	b.WriteString("\n")
+
+	// Key bindings - third row with right-aligned detach hint
+	leftKeys := fmt.Sprintf("  %s  %s  %s  %s  %s",
 		RenderKeyBinding("g", "issues"),
-		RenderKeyBinding("R", "refresh"),
 		RenderKeyBinding("t", "theme"),
 		RenderKeyBinding("w", "wizard"),
 		RenderKeyBinding("?", "config"),
@@ -241,6 +264,24 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 	return b.String()
 }
 
+// worktreeBadges returns the dashboard markers for a worktree's special states
+func worktreeBadges(wt *devcontainer.WorktreeInfo) string {
+	if wt == nil {
+		return ""
+	}
+	var badges string
+	if wt.Detached {
+		badges += " (detached)" // No branch checked out, shown by commit
+	}
+	if wt.Locked {
+		badges += " (locked)" // Protected from prune, move and removal
+	}
+	if wt.Prunable {
+		badges += " (prunable)" // Directory deleted, git worktree prune would remove it
+	}
+	return badges
+}
+
 // getStatusText returns a visual indicator with text label for container status
 func getStatusText(status devcontainer.ContainerStatus) string {
 	switch status {
@@ -281,22 +322,111 @@ func RenderNewWorktreeInput(projectName string, input interface{ View() string }
 	return b.String()
 }
 
+// RenderRenameWorktreeInput renders the text input for renaming a worktree's branch
+func RenderRenameWorktreeInput(instanceName string, input interface{ View() string }) string {
+	b := renderWithHeader("Rename Git Worktree")
+	b.WriteString("Worktree: ")
+	b.WriteString(SuccessStyle.Render(instanceName))
+	b.WriteString("\n\n")
+	b.WriteString("Enter new branch name:")
+	b.WriteString("\n\n")
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("Renames the branch and moves the worktree directory to match."))
+	b.WriteString("\n")
+	b.WriteString(DimmedStyle.Render("A running container is recreated at the new path."))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Rename  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderRenamingWorktree renders the loading state while renaming a worktree
+func RenderRenamingWorktree(branchName string, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Renaming worktree to", branchName, "Running git branch -m and git worktree move...")
+}
+
 // RenderCreatingWorktree renders the loading state while creating a new worktree
 func RenderCreatingWorktree(branchName string, spinnerView string) string {
 	return renderSpinnerWithHint(spinnerView, "Creating worktree", branchName, "Running git worktree add...")
 }
 
-// RenderConfirmDeleteWorktree renders the confirmation dialog for deleting a worktree
-func RenderConfirmDeleteWorktree(branchName string) string {
+// RenderLoadingWorktreeStatus renders the loading state while checking a worktree for unsaved work
+func RenderLoadingWorktreeStatus(branchName string, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Checking worktree", branchName, "Looking for uncommitted, unpushed and unmerged work...")
+}
+
+// maxListedChangedFiles limits how many uncommitted paths the delete dialog lists
+const maxListedChangedFiles = 5
+
+// RenderConfirmDeleteWorktree renders the confirmation dialog for deleting a worktree.
+// status is nil when the worktree could not be inspected.
+func RenderConfirmDeleteWorktree(branchName string, status *devcontainer.WorktreeStatus) string {
 	b := renderWithHeader("")
 	b.WriteString(ErrorStyle.Render("Delete worktree?"))
 	b.WriteString("\n\n")
 	b.WriteString("Branch: ")
 	b.WriteString(SuccessStyle.Render(branchName))
 	b.WriteString("\n\n")
-	b.WriteString(DimmedStyle.Render("This will remove the worktree directory and branch"))
+
+	if status == nil {
+		b.WriteString(WarningStyle.Render("Could not check worktree for unsaved work"))
+		b.WriteString("\n\n")
+		b.WriteString(HelpStyle.Render("y: Delete worktree  b: Delete worktree + branches  n/Esc: Cancel"))
+		return b.String()
+	}
+
+	// Uncommitted changes
+	if status.IsDirty() {
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("● %d uncommitted change(s)", len(status.ChangedFiles))))
+		b.WriteString("\n")
+		for i, file := range status.ChangedFiles {
+			if i == maxListedChangedFiles {
+				b.WriteString(DimmedStyle.Render(fmt.Sprintf("    ... and %d more", len(status.ChangedFiles)-i)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString(DimmedStyle.Render("    " + file))
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(SuccessStyle.Render("✓ No uncommitted changes"))
+		b.WriteString("\n")
+	}
+
+	// Unpushed commits
+	if status.UnpushedCommits > 0 {
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("● %d unpushed commit(s)", status.UnpushedCommits)))
+	} else {
+		b.WriteString(SuccessStyle.Render("✓ No unpushed commits"))
+	}
+	b.WriteString("\n")
+
+	// Merge state
+	switch {
+	case status.BaseBranch == "":
+		b.WriteString(DimmedStyle.Render("? Default branch unknown, merge state not checked"))
+	case status.Merged:
+		b.WriteString(SuccessStyle.Render("✓ Merged into " + status.BaseBranch))
+	default:
+		b.WriteString(WarningStyle.Render("● Not merged into " + status.BaseBranch))
+	}
 	b.WriteString("\n\n")
-	b.WriteString(HelpStyle.Render("y: Confirm  n/Esc: Cancel"))
+
+	if !status.IsSafeToDelete() {
+		b.WriteString(DimmedStyle.Render("Deleting now may lose work. Save it first or keep the branch."))
+		b.WriteString("\n\n")
+	}
+
+	// Options depend on what there is to save
+	options := []string{"y: Delete worktree", "b: Delete worktree + branches"}
+	if status.IsDirty() {
+		options = append(options, "s: Stash first")
+	}
+	if status.IsDirty() || status.UnpushedCommits > 0 {
+		options = append(options, "c: Commit & push first")
+	}
+	options = append(options, "n/Esc: Cancel")
+	b.WriteString(HelpStyle.Render(strings.Join(options, "  ")))
 	return b.String()
 }
 
@@ -304,3 +434,139 @@ func RenderConfirmDeleteWorktree(branchName string) string {
 func RenderDeletingWorktree(branchName string, spinnerView string) string {
 	return renderSpinnerWithHint(spinnerView, "Deleting worktree", branchName, "Running git worktree remove...")
 }
+
+// RenderIntegrateOptions renders the choice of how to integrate a worktree branch
+func RenderIntegrateOptions(branchName string, fastForward bool) string {
+	b := renderWithHeader("Integrate Worktree")
+	b.WriteString("Branch: ")
+	b.WriteString(SuccessStyle.Render(branchName))
+	b.WriteString("\n\n")
+	b.WriteString("Bring the branch up to date with its base branch on the host:")
+	b.WriteString("\n\n")
+	b.WriteString("  r: Rebase onto base\n")
+	b.WriteString("  m: Merge base into branch\n")
+	b.WriteString("\n")
+
+	check := "[ ]"
+	if fastForward {
+		check = "[x]"
+	}
+	b.WriteString(fmt.Sprintf("  f: %s Fast-forward base branch (and main worktree) afterwards\n", check))
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("r/Enter: Rebase  m: Merge  f: Toggle fast-forward  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderIntegrating renders the loading state while integrating a worktree branch
+func RenderIntegrating(branchName string, mode devcontainer.IntegrateMode, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Integrating", branchName, "Running git "+mode.String()+"...")
+}
+
+// RenderIntegrateResult renders the outcome of an integration, listing conflicts if it stopped
+func RenderIntegrateResult(result *devcontainer.IntegrateResult, mode devcontainer.IntegrateMode) string {
+	b := renderWithHeader("Integrate Worktree")
+	if result == nil {
+		return b.String()
+	}
+
+	if len(result.Conflicts) > 0 {
+		b.WriteString(ErrorStyle.Render(fmt.Sprintf("Conflicts integrating %s with %s:", result.Branch, result.Base)))
+		b.WriteString("\n\n")
+		for _, file := range result.Conflicts {
+			b.WriteString("  " + WarningStyle.Render("● ") + file + "\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(DimmedStyle.Render("Resolve the conflicts in the worktree and continue, or abort to restore the branch."))
+		b.WriteString("\n\n")
+		b.WriteString(HelpStyle.Render("a: Abort  Any other key: Keep and return"))
+		return b.String()
+	}
+
+	verb := "rebased onto"
+	if mode == devcontainer.IntegrateMerge {
+		verb = "merged with"
+	}
+	b.WriteString(SuccessStyle.Render(fmt.Sprintf("✓ %s %s %s", result.Branch, verb, result.Base)))
+	b.WriteString("\n")
+	if result.FastForwarded {
+		b.WriteString(SuccessStyle.Render(fmt.Sprintf("✓ %s fast-forwarded to %s", result.Base, result.Branch)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("Press any key to continue"))
+	return b.String()
+}
+
+// RenderCleanupScanning renders the loading state while looking for finished worktrees
+func RenderCleanupScanning(spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Finding finished worktrees", "", "Checking merged branches, pull requests and issues...")
+}
+
+// RenderCleanupList renders the list of finished worktrees selectable for removal
+func RenderCleanupList(candidates []devcontainer.CleanupCandidate, selected map[int]bool, cursor int, warning string, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+	b.WriteString(RenderBorderedHeader("claude-quick", "Clean Up Worktrees", width))
+	b.WriteString("\n\n")
+
+	if warning != "" {
+		b.WriteString(WarningStyle.Render("Warning: " + warning))
+		b.WriteString("\n\n")
+	}
+
+	if len(candidates) == 0 {
+		b.WriteString(SuccessStyle.Render("No finished worktrees found."))
+		b.WriteString("\n\n")
+		b.WriteString(HelpStyle.Render("q/Esc: Back"))
+		return b.String()
+	}
+
+	now := time.Now()
+	for i, c := range candidates {
+		checkbox := "[ ]"
+		if selected[i] {
+			checkbox = "[x]"
+		}
+		name := checkbox + " " + c.Instance.DisplayName()
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(name))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(name))
+		}
+		b.WriteString("\n")
+
+		details := c.Reason
+		if !c.LastActivity.IsZero() {
+			details += " · last commit " + formatAge(c.LastActivity, now)
+		}
+		b.WriteString("      " + DimmedStyle.Render(details))
+		b.WriteString("\n")
+		if c.AtRisk != "" {
+			b.WriteString("      " + WarningStyle.Render("Removing loses "+c.AtRisk))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(DimmedStyle.Render("Removes the worktree, local branch, stopped container and credential file."))
+	b.WriteString("\n\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	keybindings := fmt.Sprintf("  %s  %s  %s  %s  %s",
+		RenderKeyBinding("↑↓", "navigate"),
+		RenderKeyBinding("space", "toggle"),
+		RenderKeyBinding("a", "all"),
+		RenderKeyBinding("enter", fmt.Sprintf("remove %d", countSelected(selected))),
+		RenderKeyBinding("q", "back"),
+	)
+	b.WriteString(keybindings)
+	return b.String()
+}
+
+// RenderCleaningUp renders the loading state while removing finished worktrees
+func RenderCleaningUp(count int, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Removing", fmt.Sprintf("%d worktree(s)", count), "Removing worktrees, branches, containers and credential files...")
+}
@@ -0,0 +1,312 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+func TestRenderSimpleHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtitle string
+	}{
+		{"with subtitle", "Container Dashboard"},
+		{"empty subtitle", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := renderSimpleHeader(tt.subtitle)
+			content := result.String()
+
+			// Should contain the app title
+			if !strings.Contains(content, "claude-quick") {
+				t.Error("renderSimpleHeader should contain 'claude-quick'")
+			}
+
+			// Should contain subtitle if provided
+			if tt.subtitle != "" && !strings.Contains(content, tt.subtitle) {
+				t.Errorf("renderSimpleHeader should contain subtitle %q", tt.subtitle)
+			}
+		})
+	}
+}
+
+func TestRenderWithHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtitle string
+	}{
+		{"with subtitle", "Container Dashboard"},
+		{"empty subtitle", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := renderWithHeader(tt.subtitle)
+			content := result.String()
+
+			// Should contain the app title
+			if !strings.Contains(content, "claude-quick") {
+				t.Error("renderWithHeader should contain 'claude-quick'")
+			}
+
+			// Should contain border characters (from RenderBorderedHeader)
+			if !strings.Contains(content, "┌") {
+				t.Error("renderWithHeader should contain bordered header")
+			}
+		})
+	}
+}
+
+func TestRenderConfirmDialog(t *testing.T) {
+	tests := []struct {
+		name       string
+		operation  string
+		entityType string
+		labelType  string
+		entityName string
+		contains   []string
+	}{
+		{
+			name:       "stop container",
+			operation:  "stop",
+			entityType: "container",
+			labelType:  "Project",
+			entityName: "myproject",
+			contains:   []string{"Stop", "container", "Project", "myproject", "Confirm", "Cancel"},
+		},
+		{
+			name:       "restart container",
+			operation:  "restart",
+			entityType: "container",
+			labelType:  "Project",
+			entityName: "myapp",
+			contains:   []string{"Restart", "container", "Project", "myapp"},
+		},
+		{
+			name:       "stop tmux session",
+			operation:  "stop",
+			entityType: "tmux session",
+			labelType:  "Session",
+			entityName: "main",
+			contains:   []string{"Stop", "tmux session", "Session", "main"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := renderConfirmDialog(tt.operation, tt.entityType, tt.labelType, tt.entityName)
+
+			for _, expected := range tt.contains {
+				if !strings.Contains(result, expected) {
+					t.Errorf("renderConfirmDialog should contain %q", expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderOperation(t *testing.T) {
+	tests := []struct {
+		name       string
+		operation  string
+		entityType string
+		entityName string
+		spinner    string
+	}{
+		{"with entity type", "Stopping", "session", "main", "⠋"},
+		{"without entity type", "Stopping", "", "myproject", "⠋"},
+		{"restart operation", "Restarting", "", "myapp", "⠙"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := renderOperation(tt.operation, tt.entityType, tt.entityName, tt.spinner)
+
+			// Should contain operation
+			if !strings.Contains(result, tt.operation) {
+				t.Errorf("renderOperation should contain operation %q", tt.operation)
+			}
+
+			// Should contain entity name
+			if !strings.Contains(result, tt.entityName) {
+				t.Errorf("renderOperation should contain entity name %q", tt.entityName)
+			}
+
+			// Should contain entity type if provided
+			if tt.entityType != "" && !strings.Contains(result, tt.entityType) {
+				t.Errorf("renderOperation should contain entity type %q", tt.entityType)
+			}
+
+			// Should contain spinner
+			if !strings.Contains(result, tt.spinner) {
+				t.Errorf("renderOperation should contain spinner %q", tt.spinner)
+			}
+
+			// Should end with ...
+			if !strings.HasSuffix(result, "...") {
+				t.Error("renderOperation should end with ...")
+			}
+		})
+	}
+}
+
+func TestWorktreeBadges(t *testing.T) {
+	tests := []struct {
+		name     string
+		wt       *devcontainer.WorktreeInfo
+		expected string
+	}{
+		{"not a worktree", nil, ""},
+		{"plain branch", &devcontainer.WorktreeInfo{Branch: "feature"}, ""},
+		{"detached", &devcontainer.WorktreeInfo{Detached: true}, " (detached)"},
+		{"locked", &devcontainer.WorktreeInfo{Branch: "feature", Locked: true}, " (locked)"},
+		{"prunable", &devcontainer.WorktreeInfo{Branch: "feature", Prunable: true}, " (prunable)"},
+		{"detached and locked", &devcontainer.WorktreeInfo{Detached: true, Locked: true}, " (detached) (locked)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := worktreeBadges(tt.wt); got != tt.expected {
+				t.Errorf("worktreeBadges() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// worktree deletion tests
+// ============================================================================
+
+func TestRenderConfirmDeleteWorktree(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      *devcontainer.WorktreeStatus
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "status unavailable",
+			status:   nil,
+			contains: []string{"feature", "Could not check", "y: Delete worktree", "b: Delete worktree + branches"},
+		},
+		{
+			name:        "safe to delete",
+			status:      &devcontainer.WorktreeStatus{BaseBranch: "main", Merged: true},
+			contains:    []string{"No uncommitted changes", "No unpushed commits", "Merged into main"},
+			notContains: []string{"s: Stash first", "c: Commit & push first", "may lose work"},
+		},
+		{
+			name: "dirty, unpushed and unmerged",
+			status: &devcontainer.WorktreeStatus{
+				BaseBranch:      "main",
+				ChangedFiles:    []string{"a.go", "b.go"},
+				UnpushedCommits: 3,
+			},
+			contains: []string{"2 uncommitted change(s)", "a.go", "3 unpushed commit(s)", "Not merged into main",
+				"may lose work", "s: Stash first", "c: Commit & push first"},
+		},
+		{
+			name:        "unpushed only offers commit & push",
+			status:      &devcontainer.WorktreeStatus{BaseBranch: "main", UnpushedCommits: 1},
+			contains:    []string{"c: Commit & push first"},
+			notContains: []string{"s: Stash first"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderConfirmDeleteWorktree("feature", tt.status)
+			for _, want := range tt.contains {
+				if !strings.Contains(result, want) {
+					t.Errorf("RenderConfirmDeleteWorktree() should contain %q", want)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(result, unwanted) {
+					t.Errorf("RenderConfirmDeleteWorktree() should not contain %q", unwanted)
+				}
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Bulk cleanup tests
+// ============================================================================
+
+func TestRenderCleanupList(t *testing.T) {
+	wt := &devcontainer.WorktreeInfo{Branch: "feature-x", MainRepo: "/repo"}
+	candidates := []devcontainer.CleanupCandidate{
+		{
+			Instance: devcontainer.ContainerInstance{
+				Project:  devcontainer.Project{Name: "repo", Path: "/repo-feature-x"},
+				Worktree: wt,
+			},
+			Reason:       "merged into main",
+			LastActivity: time.Now().Add(-48 * time.Hour),
+		},
+	}
+
+	result := RenderCleanupList(candidates, map[int]bool{0: true}, 0, "", 80)
+	for _, want := range []string{"[x]", "repo [feature-x]", "merged into main", "2d ago", "remove 1"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("RenderCleanupList() should contain %q", want)
+		}
+	}
+
+	candidates[0].AtRisk = "2 uncommitted file(s)"
+	if result := RenderCleanupList(candidates, nil, 0, "", 80); !strings.Contains(result, "Removing loses 2 uncommitted file(s)") {
+		t.Error("RenderCleanupList() should flag work a removal would lose")
+	}
+
+	empty := RenderCleanupList(nil, nil, 0, "", 80)
+	if !strings.Contains(empty, "No finished worktrees found") {
+		t.Error("RenderCleanupList() with no candidates should say nothing was found")
+	}
+}
+
+// ============================================================================
+// Worktree integration tests
+// ============================================================================
+
+func TestRenderIntegrateResult(t *testing.T) {
+	success := RenderIntegrateResult(&devcontainer.IntegrateResult{
+		Branch: "feature", Base: "main", FastForwarded: true,
+	}, devcontainer.IntegrateRebase)
+	for _, want := range []string{"feature rebased onto main", "main fast-forwarded"} {
+		if !strings.Contains(success, want) {
+			t.Errorf("success view should contain %q", want)
+		}
+	}
+
+	conflicts := RenderIntegrateResult(&devcontainer.IntegrateResult{
+		Branch: "feature", Base: "main", Conflicts: []string{"go.mod", "main.go"},
+	}, devcontainer.IntegrateMerge)
+	for _, want := range []string{"Conflicts integrating feature with main", "go.mod", "main.go", "a: Abort"} {
+		if !strings.Contains(conflicts, want) {
+			t.Errorf("conflict view should contain %q", want)
+		}
+	}
+}
+
+// ============================================================================
+// Pane preview tests
+// ============================================================================
+
+func TestRenderDashboard_AgentBadge(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{{
+		ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+		Status:            devcontainer.StatusRunning,
+		SessionCount:      3,
+		AgentStates:       map[string]tmux.AgentState{"a": tmux.AgentWaiting, "b": tmux.AgentWaiting, "c": tmux.AgentIdle},
+	}}
+	if view := RenderDashboard(instances, 0, 0, "", nil); !strings.Contains(view, "2 waiting") {
+		t.Error("dashboard row should show the most urgent agent state")
+	}
+}
@@ -2,11 +2,13 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 // handleKeyPress processes keyboard input based on current state
@@ -24,8 +26,26 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleTmuxSelectKey(msg)
 	case StateNewSessionInput:
 		return m.handleNewSessionInputKey(msg)
+	case StateTmuxNameInput:
+		return m.handleTmuxNameInputKey(msg)
+	case StateConfirmRestoreSessions:
+		return m.handleRestoreSessionsKey(msg)
+	case StateTmuxMoveWindow:
+		return m.handleTmuxMoveWindowKey(msg)
 	case StateNewWorktreeInput:
 		return m.handleNewWorktreeInputKey(msg)
+	case StateRenameWorktreeInput:
+		return m.handleRenameWorktreeInputKey(msg)
+	case StateIntegrateOptions:
+		return m.handleIntegrateOptionsKey(msg)
+	case StateIntegrateResult:
+		return m.handleIntegrateResultKey(msg)
+	case StateCleanupList:
+		return m.handleCleanupListKey(msg)
+	case StateBroadcastSelect:
+		return m.handleBroadcastSelectKey(msg)
+	case StateSendKeysInput:
+		return m.handleSendKeysInputKey(msg)
 	case StateGitHubIssuesList:
 		return m.handleGitHubIssuesListKey(msg)
 	case StateGitHubIssueDetail:
@@ -35,9 +55,18 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = StateDashboard
 		m.err = nil
 		return m, nil
+	case StateScrollbackExported:
+		// Any key returns to the session picker
+		m.state = StateTmuxSelect
+		m.selectedSession = nil
+		m.exportedPaths = nil
+		return m, m.startPanePreview()
 	case StateShowConfig:
 		// Any key returns to previous state
 		m.state = m.previousState
+		if m.state == StateTmuxSelect {
+			return m, m.startPanePreview()
+		}
 		return m, nil
 
 	case StateWizardWelcome, StateWizardSearchPaths, StateWizardCredentials,
@@ -65,6 +94,7 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		if len(m.instancesStatus) > 0 {
 			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
+			delete(m.attention, m.selectedInstance.Path) // Acknowledged by connecting
 			if m.instancesStatus[m.cursor].Status == devcontainer.StatusRunning {
 				// Container is running, load tmux sessions
 				m.state = StateLoadingTmuxSessions
@@ -116,24 +146,80 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "d":
 		// Delete worktree - only for non-main worktrees
 		if len(m.instancesStatus) > 0 {
-			selected := &m.instancesStatus[m.cursor].ContainerInstance
-			// Only allow deleting non-main worktrees
-			if selected.Worktree == nil {
-				m.state = StateError
-				m.err = fmt.Errorf("cannot delete: not a git worktree")
-				m.errHint = "Press any key to go back"
-				return m, nil
+			selected, err := m.selectedNonMainWorktree("delete")
+			if err != nil {
+				return m.showError(err)
 			}
-			if selected.Worktree.IsMain {
-				m.state = StateError
-				m.err = fmt.Errorf("cannot delete the main worktree")
-				m.errHint = "Press any key to go back"
-				return m, nil
+			m.selectedInstance = selected
+			m.state = StateLoadingWorktreeStatus
+			return m, tea.Batch(m.spinner.Tick, m.loadWorktreeStatus())
+		}
+
+	case "l":
+		// Lock/unlock worktree to protect it from prune and removal
+		if len(m.instancesStatus) > 0 {
+			selected, err := m.selectedNonMainWorktree("lock")
+			if err != nil {
+				return m.showError(err)
 			}
 			m.selectedInstance = selected
-			m.state = StateConfirmDeleteWorktree
+			return m, m.toggleWorktreeLock()
 		}
 
+	case "m":
+		// Rename worktree branch and move its directory to match
+		if len(m.instancesStatus) > 0 {
+			selected, err := m.selectedNonMainWorktree("rename")
+			if err != nil {
+				return m.showError(err)
+			}
+			if selected.Worktree.Locked {
+				return m.showError(fmt.Errorf("cannot rename: worktree is locked (press l to unlock)"))
+			}
+			if selected.Worktree.Detached {
+				return m.showError(fmt.Errorf("cannot rename: worktree has a detached HEAD"))
+			}
+			m.selectedInstance = selected
+			m.state = StateRenameWorktreeInput
+			m.worktreeInput.SetValue(selected.Worktree.Branch)
+			m.worktreeInput.CursorEnd()
+			m.worktreeInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "i":
+		// Integrate worktree branch into its base branch
+		if len(m.instancesStatus) > 0 {
+			selected, err := m.selectedNonMainWorktree("integrate")
+			if err != nil {
+				return m.showError(err)
+			}
+			m.selectedInstance = selected
+			// A stopped rebase detaches HEAD, so this comes before the detached check
+			if devcontainer.IntegrationInProgress(*selected.Worktree) {
+				// Show the stopped rebase/merge directly so it can be aborted
+				m.state = StateIntegrating
+				return m, tea.Batch(m.spinner.Tick, m.integrateWorktree())
+			}
+			if selected.Worktree.Detached {
+				return m.showError(fmt.Errorf("cannot integrate: worktree has a detached HEAD"))
+			}
+			m.integrateMode = devcontainer.IntegrateRebase
+			m.integrateFastForward = true
+			m.state = StateIntegrateOptions
+		}
+
+	case "c":
+		// Bulk cleanup of finished worktrees across all projects
+		m.state = StateCleanupScanning
+		return m, tea.Batch(m.spinner.Tick, m.scanCleanupCandidates())
+
+	case "b":
+		// Broadcast text to sessions across all running containers
+		m.sendBroadcast = true
+		m.state = StateBroadcastLoading
+		return m, tea.Batch(m.spinner.Tick, m.loadBroadcastTargets())
+
 	case "?":
 		m.previousState = m.state
 		m.state = StateShowConfig
@@ -172,6 +258,27 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// selectedNonMainWorktree returns the instance under the cursor if it is a
+// non-main git worktree, or an error naming the attempted action
+func (m Model) selectedNonMainWorktree(action string) (*devcontainer.ContainerInstance, error) {
+	selected := &m.instancesStatus[m.cursor].ContainerInstance
+	if selected.Worktree == nil {
+		return nil, fmt.Errorf("cannot %s: not a git worktree", action)
+	}
+	if selected.Worktree.IsMain {
+		return nil, fmt.Errorf("cannot %s the main worktree", action)
+	}
+	return selected, nil
+}
+
+// showError switches to the error view with the default hint
+func (m Model) showError(err error) (tea.Model, tea.Cmd) {
+	m.state = StateError
+	m.err = err
+	m.errHint = "Press any key to go back"
+	return m, nil
+}
+
 func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
@@ -203,7 +310,8 @@ func (m Model) handleTmuxConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "n", "N", "esc":
 		m.state = StateTmuxSelect
 		m.selectedSession = nil
-		return m, nil
+		m.selectedWindow = nil
+		return m, m.startPanePreview()
 	case "ctrl+c":
 		return m, tea.Quit
 	}
@@ -212,6 +320,7 @@ func (m Model) handleTmuxConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) handleTmuxSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	totalOptions := TotalTmuxOptions(m.tmuxSessions)
+	session, window := tmuxRowAt(m.tmuxSessions, m.cursor)
 
 	switch msg.String() {
 	case "q", "esc":
@@ -235,19 +344,89 @@ func (m Model) handleTmuxSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "x":
-		// Stop/kill selected tmux session (only for existing sessions)
-		if m.cursor < len(m.tmuxSessions) {
-			m.selectedSession = &m.tmuxSessions[m.cursor]
+		// Stop/kill selected tmux session, or the selected window on a window row
+		if session != nil {
+			m.selectedSession = session
+			m.selectedWindow = window
 			m.state = StateConfirmTmuxStop
 		}
 
 	case "r":
 		// Restart selected tmux session (only for existing sessions)
-		if m.cursor < len(m.tmuxSessions) {
-			m.selectedSession = &m.tmuxSessions[m.cursor]
+		if session != nil {
+			m.selectedSession = session
+			m.selectedWindow = nil
 			m.state = StateConfirmTmuxRestart
 		}
 
+	case "e":
+		// Rename the selected session, or the selected window on a window row
+		if session != nil {
+			m.selectedSession = session
+			m.selectedWindow = window
+			m.tmuxNameAction = renameSession
+			m.tmuxNameInput.SetValue(session.Name)
+			if window != nil {
+				m.tmuxNameAction = renameWindow
+				m.tmuxNameInput.SetValue(window.Name)
+			}
+			m.state = StateTmuxNameInput
+			m.tmuxNameInput.CursorEnd()
+			m.tmuxNameInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "w":
+		// Create a window in the selected session
+		if session != nil {
+			m.selectedSession = session
+			m.selectedWindow = nil
+			m.tmuxNameAction = newWindow
+			m.tmuxNameInput.SetValue("")
+			m.state = StateTmuxNameInput
+			m.tmuxNameInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "m":
+		// Move the selected window to another session
+		if window != nil && len(m.tmuxSessions) > 1 {
+			m.selectedSession = session
+			m.selectedWindow = window
+			m.moveCursor = 0
+			m.state = StateTmuxMoveWindow
+		}
+
+	case "o":
+		// Observe the selected session or window read-only
+		if window != nil {
+			return m.observeSession(tmux.WindowTarget(session.Name, window.Index))
+		}
+		if session != nil {
+			return m.observeSession(session.Name)
+		}
+
+	case "a":
+		// Export the selected session's full scrollback to the host
+		if session != nil {
+			m.selectedSession = session
+			m.selectedWindow = nil
+			m.state = StateExportingScrollback
+			return m, tea.Batch(m.spinner.Tick, m.exportScrollback())
+		}
+
+	case "s":
+		// Type text into the selected session without attaching
+		if session != nil && m.selectedInstance != nil {
+			m.sendTargets = []sendTarget{{instance: *m.selectedInstance, session: session.Name}}
+			m.sendSelected = map[int]bool{0: true}
+			m.sendBroadcast = false
+			m.state = StateSendKeysInput
+			m.sendInput.SetValue("")
+			m.sendInput.Focus()
+			return m, textinput.Blink
+		}
+
 	case "?":
 		m.previousState = m.state
 		m.state = StateShowConfig
@@ -263,13 +442,101 @@ func (m Model) handleTmuxSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if IsNewSessionSelected(m.tmuxSessions, m.cursor) {
 			// Show text input for new session name
 			m.state = StateNewSessionInput
+			m.sessionTemplate = m.config.TmuxTemplateFor(m.selectedInstance.Name)
 			m.textInput.SetValue("")
 			m.textInput.Focus()
 			return m, textinput.Blink
 		}
-		// Attach to existing session
-		if m.cursor < len(m.tmuxSessions) {
-			return m.attachToSession(m.tmuxSessions[m.cursor].Name)
+		// Attach to existing session, on the selected window for window rows
+		if window != nil {
+			return m.attachToSession(tmux.WindowTarget(session.Name, window.Index))
+		}
+		if session != nil {
+			return m.attachToSession(session.Name)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleRestoreSessionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		m.state = StateRestoringSessions
+		return m, tea.Batch(m.spinner.Tick, m.restoreSessions(false))
+	case "c", "C":
+		// Restore and resume the agents' conversations
+		m.state = StateRestoringSessions
+		return m, tea.Batch(m.spinner.Tick, m.restoreSessions(true))
+	case "n", "N":
+		// Forget the saved sessions
+		m.state = StateLoadingTmuxSessions
+		return m, tea.Batch(m.spinner.Tick, m.discardSavedSessions())
+	case "esc":
+		// Keep the saved sessions for next time
+		m.savedSessions = nil
+		m.state = StateTmuxSelect
+		return m, m.startPanePreview()
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) handleTmuxNameInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Cancel and go back to tmux select
+		m.state = StateTmuxSelect
+		m.selectedSession = nil
+		m.selectedWindow = nil
+		return m, m.startPanePreview()
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		name := strings.TrimSpace(m.tmuxNameInput.Value())
+		if name == "" && m.tmuxNameAction != newWindow {
+			// Nothing to rename to
+			return m, nil
+		}
+		m.state = StateTmuxUpdating
+		return m, tea.Batch(m.spinner.Tick, m.applyTmuxName(name))
+	}
+
+	// Pass other keys to text input
+	var cmd tea.Cmd
+	m.tmuxNameInput, cmd = m.tmuxNameInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleTmuxMoveWindowKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	destinations := m.moveDestinations()
+
+	switch msg.String() {
+	case "esc", "q":
+		m.state = StateTmuxSelect
+		m.selectedSession = nil
+		m.selectedWindow = nil
+		return m, m.startPanePreview()
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.moveCursor > 0 {
+			m.moveCursor--
+		}
+
+	case "down", "j":
+		if m.moveCursor < len(destinations)-1 {
+			m.moveCursor++
+		}
+
+	case "enter":
+		if m.moveCursor < len(destinations) {
+			m.state = StateTmuxUpdating
+			return m, tea.Batch(m.spinner.Tick, m.moveTmuxWindow(destinations[m.moveCursor]))
 		}
 	}
 	return m, nil
@@ -280,11 +547,20 @@ func (m Model) handleNewSessionInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		// Cancel and go back to tmux select
 		m.state = StateTmuxSelect
-		return m, nil
+		return m, m.startPanePreview()
 
 	case "ctrl+c":
 		return m, tea.Quit
 
+	case "tab", "shift+tab":
+		// Cycle through session templates
+		step := 1
+		if msg.String() == "shift+tab" {
+			step = -1
+		}
+		m.sessionTemplate = m.nextSessionTemplate(step)
+		return m, nil
+
 	case "enter":
 		name := m.textInput.Value()
 		if name == "" {
@@ -317,11 +593,11 @@ func (m Model) handleNewWorktreeInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		branchName := m.worktreeInput.Value()
 		if branchName == "" {
 			m.state = StateError
-			m.err = devcontainer.ValidateBranchName("")
+			m.err = devcontainer.ValidateBranchName("", nil)
 			m.errHint = "Press any key to go back"
 			return m, nil
 		}
-		if err := devcontainer.ValidateBranchName(branchName); err != nil {
+		if err := devcontainer.ValidateBranchName(branchName, m.worktreeConfig().ReservedBranchPatterns()); err != nil {
 			m.state = StateError
 			m.err = err
 			m.errHint = "Press any key to go back"
@@ -340,21 +616,237 @@ func (m Model) handleNewWorktreeInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) handleRenameWorktreeInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDashboard
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		branchName := m.worktreeInput.Value()
+		if err := devcontainer.ValidateBranchName(branchName, m.worktreeConfig().ReservedBranchPatterns()); err != nil {
+			return m.showError(err)
+		}
+		if m.selectedInstance != nil && m.selectedInstance.Worktree != nil &&
+			branchName == m.selectedInstance.Worktree.Branch {
+			m.state = StateDashboard
+			return m, nil
+		}
+		m.state = StateRenamingWorktree
+		return m, tea.Batch(
+			m.spinner.Tick,
+			m.renameWorktree(branchName),
+		)
+	}
+
+	var cmd tea.Cmd
+	m.worktreeInput, cmd = m.worktreeInput.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleConfirmDeleteWorktreeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
-		m.state = StateDeletingWorktree
-		return m, tea.Batch(m.spinner.Tick, m.deleteWorktree())
+		m.worktreeDeleteMode = deleteWorktreeOnly
+	case "b":
+		m.worktreeDeleteMode = deleteWorktreeAndBranches
+	case "s":
+		// Stashing only makes sense when there is something to stash
+		if m.worktreeStatus == nil || !m.worktreeStatus.IsDirty() {
+			return m, nil
+		}
+		m.worktreeDeleteMode = deleteWorktreeAfterStash
+	case "c":
+		// Commit & push only makes sense when there is unsaved or unpushed work
+		if m.worktreeStatus == nil || (!m.worktreeStatus.IsDirty() && m.worktreeStatus.UnpushedCommits == 0) {
+			return m, nil
+		}
+		m.worktreeDeleteMode = deleteWorktreeAfterPush
 	case "n", "N", "esc":
 		m.state = StateDashboard
 		m.selectedInstance = nil
+		m.worktreeStatus = nil
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+
+	m.state = StateDeletingWorktree
+	return m, tea.Batch(m.spinner.Tick, m.deleteWorktree())
+}
+
+func (m Model) handleIntegrateOptionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r", "enter":
+		m.integrateMode = devcontainer.IntegrateRebase
+	case "m":
+		m.integrateMode = devcontainer.IntegrateMerge
+	case "f":
+		// Toggle fast-forwarding the base branch after integrating
+		m.integrateFastForward = !m.integrateFastForward
+		return m, nil
+	case "n", "esc":
+		m.state = StateDashboard
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+
+	m.state = StateIntegrating
+	return m, tea.Batch(m.spinner.Tick, m.integrateWorktree())
+}
+
+func (m Model) handleIntegrateResultKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "a":
+		// Abort the stopped rebase/merge
+		if m.integrateResult != nil && len(m.integrateResult.Conflicts) > 0 {
+			m.state = StateIntegrating
+			return m, tea.Batch(m.spinner.Tick, m.abortIntegration())
+		}
+	}
+
+	// Any other key returns to the dashboard; conflicts stay for resolving in the worktree
+	if m.integrateResult != nil && len(m.integrateResult.Conflicts) > 0 {
+		m.warning = fmt.Sprintf("%s has unresolved conflicts - resolve them in the worktree or press i then a to abort",
+			m.getInstanceName())
+	}
+	m.integrateResult = nil
+	m.state = StateDiscovering
+	return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+}
+
+func (m Model) handleCleanupListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateDashboard
+		m.cleanupCandidates = nil
+		m.cleanupSelected = nil
+		m.warning = ""
+		m.cursor = 0
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.cleanupCandidates)-1 {
+			m.cursor++
+		}
+
+	case " ", "x":
+		// Toggle selection of the highlighted worktree
+		if m.cursor < len(m.cleanupCandidates) {
+			m.cleanupSelected[m.cursor] = !m.cleanupSelected[m.cursor]
+		}
+
+	case "a":
+		// Select all, or deselect all if everything is already selected
+		selectAll := countSelected(m.cleanupSelected) < len(m.cleanupCandidates)
+		for i := range m.cleanupCandidates {
+			m.cleanupSelected[i] = selectAll
+		}
+
+	case "enter", "y":
+		if countSelected(m.cleanupSelected) > 0 {
+			m.state = StateCleaningUp
+			return m, tea.Batch(m.spinner.Tick, m.cleanupWorktrees())
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleBroadcastSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateDashboard
+		m.sendTargets = nil
+		m.sendSelected = nil
+		m.cursor = 0
 		return m, nil
+
 	case "ctrl+c":
 		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.sendTargets)-1 {
+			m.cursor++
+		}
+
+	case " ", "x":
+		// Toggle selection of the highlighted session
+		if m.cursor < len(m.sendTargets) {
+			m.sendSelected[m.cursor] = !m.sendSelected[m.cursor]
+		}
+
+	case "a":
+		// Select all, or deselect all if everything is already selected
+		selectAll := countSelected(m.sendSelected) < len(m.sendTargets)
+		for i := range m.sendTargets {
+			m.sendSelected[i] = selectAll
+		}
+
+	case "w":
+		// Select exactly the agents waiting for input
+		for i, target := range m.sendTargets {
+			m.sendSelected[i] = target.state == tmux.AgentWaiting
+		}
+
+	case "enter":
+		if countSelected(m.sendSelected) > 0 {
+			m.state = StateSendKeysInput
+			m.sendInput.SetValue("")
+			m.sendInput.Focus()
+			return m, textinput.Blink
+		}
 	}
 	return m, nil
 }
 
+func (m Model) handleSendKeysInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Back to where the targets were chosen
+		if m.sendBroadcast {
+			m.state = StateBroadcastSelect
+			return m, nil
+		}
+		m.state = StateTmuxSelect
+		return m, m.startPanePreview()
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		m.state = StateSendingKeys
+		return m, tea.Batch(m.spinner.Tick, m.sendKeys())
+	}
+
+	// Pass other keys to text input
+	var cmd tea.Cmd
+	m.sendInput, cmd = m.sendInput.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleGitHubIssuesListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "q", "esc":
@@ -0,0 +1,557 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+// ============================================================================
+// worktree deletion tests
+// ============================================================================
+
+func TestHandleConfirmDeleteWorktreeKey(t *testing.T) {
+	dirty := &devcontainer.WorktreeStatus{ChangedFiles: []string{"a.go"}}
+	clean := &devcontainer.WorktreeStatus{Merged: true}
+
+	tests := []struct {
+		name          string
+		key           string
+		status        *devcontainer.WorktreeStatus
+		expectedState State
+		expectedMode  worktreeDeleteMode
+	}{
+		{"y deletes worktree only", "y", clean, StateDeletingWorktree, deleteWorktreeOnly},
+		{"b deletes branches too", "b", clean, StateDeletingWorktree, deleteWorktreeAndBranches},
+		{"s stashes dirty worktree", "s", dirty, StateDeletingWorktree, deleteWorktreeAfterStash},
+		{"s ignored for clean worktree", "s", clean, StateConfirmDeleteWorktree, deleteWorktreeOnly},
+		{"c pushes dirty worktree", "c", dirty, StateDeletingWorktree, deleteWorktreeAfterPush},
+		{"c ignored for clean worktree", "c", clean, StateConfirmDeleteWorktree, deleteWorktreeOnly},
+		{"n cancels", "n", dirty, StateDashboard, deleteWorktreeOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				state:          StateConfirmDeleteWorktree,
+				worktreeStatus: tt.status,
+			}
+			newModel, _ := m.handleConfirmDeleteWorktreeKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			model := newModel.(Model)
+
+			if model.state != tt.expectedState {
+				t.Errorf("state = %v, want %v", model.state, tt.expectedState)
+			}
+			if model.worktreeDeleteMode != tt.expectedMode {
+				t.Errorf("worktreeDeleteMode = %v, want %v", model.worktreeDeleteMode, tt.expectedMode)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Bulk cleanup tests
+// ============================================================================
+
+func TestHandleCleanupListKey(t *testing.T) {
+	candidates := make([]devcontainer.CleanupCandidate, 2)
+
+	tests := []struct {
+		name             string
+		keys             []string
+		expectedState    State
+		expectedSelected int
+	}{
+		{"space toggles current", []string{" "}, StateCleanupList, 1},
+		{"a deselects all when all selected", []string{"a"}, StateCleanupList, 0},
+		{"a reselects all", []string{"a", "a"}, StateCleanupList, 2},
+		{"enter starts cleanup", []string{"enter"}, StateCleaningUp, 2},
+		{"enter ignored with nothing selected", []string{"a", "enter"}, StateCleanupList, 0},
+		{"esc returns to dashboard", []string{"esc"}, StateDashboard, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				state:             StateCleanupList,
+				cleanupCandidates: candidates,
+				cleanupSelected:   map[int]bool{0: true, 1: true},
+			}
+			for _, key := range tt.keys {
+				var msg tea.KeyMsg
+				switch key {
+				case "enter":
+					msg = tea.KeyMsg{Type: tea.KeyEnter}
+				case "esc":
+					msg = tea.KeyMsg{Type: tea.KeyEsc}
+				case " ":
+					msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+				default:
+					msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+				}
+				newModel, _ := m.handleCleanupListKey(msg)
+				m = newModel.(Model)
+			}
+
+			if m.state != tt.expectedState {
+				t.Errorf("state = %v, want %v", m.state, tt.expectedState)
+			}
+			if tt.expectedState != StateDashboard {
+				if got := countSelected(m.cleanupSelected); got != tt.expectedSelected {
+					t.Errorf("selected = %d, want %d", got, tt.expectedSelected)
+				}
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Worktree lock and rename tests
+// ============================================================================
+
+func TestHandleDashboardKey_WorktreeOperations(t *testing.T) {
+	mainWt := &devcontainer.WorktreeInfo{Branch: "main", IsMain: true}
+	featureWt := &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/repo"}
+	lockedWt := &devcontainer.WorktreeInfo{Branch: "agent", MainRepo: "/repo", Locked: true}
+	detachedWt := &devcontainer.WorktreeInfo{MainRepo: "/repo", Detached: true, Head: "0123456789abcdef"}
+
+	instance := func(wt *devcontainer.WorktreeInfo) devcontainer.ContainerInstanceWithStatus {
+		return devcontainer.ContainerInstanceWithStatus{
+			ContainerInstance: devcontainer.ContainerInstance{
+				Project:  devcontainer.Project{Name: "repo", Path: "/repo-" + wt.Branch},
+				Worktree: wt,
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		key           string
+		wt            *devcontainer.WorktreeInfo
+		expectedState State
+		expectCmd     bool
+	}{
+		{"rename opens input", "m", featureWt, StateRenameWorktreeInput, true},
+		{"rename main worktree fails", "m", mainWt, StateError, false},
+		{"rename locked worktree fails", "m", lockedWt, StateError, false},
+		{"rename detached worktree fails", "m", detachedWt, StateError, false},
+		{"integrate detached worktree fails", "i", detachedWt, StateError, false},
+		{"lock runs command", "l", featureWt, StateDashboard, true},
+		{"lock main worktree fails", "l", mainWt, StateError, false},
+		{"delete main worktree fails", "d", mainWt, StateError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				state:           StateDashboard,
+				instancesStatus: []devcontainer.ContainerInstanceWithStatus{instance(tt.wt)},
+				worktreeInput:   newTextInput(""),
+			}
+			newModel, cmd := m.handleDashboardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			model := newModel.(Model)
+
+			if model.state != tt.expectedState {
+				t.Errorf("state = %v, want %v", model.state, tt.expectedState)
+			}
+			if (cmd != nil) != tt.expectCmd {
+				t.Errorf("cmd returned = %v, want %v", cmd != nil, tt.expectCmd)
+			}
+			if tt.expectedState == StateRenameWorktreeInput && model.worktreeInput.Value() != tt.wt.Branch {
+				t.Errorf("rename input = %q, want current branch %q", model.worktreeInput.Value(), tt.wt.Branch)
+			}
+		})
+	}
+}
+
+func TestHandleRenameWorktreeInputKey(t *testing.T) {
+	wt := &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/repo"}
+	inst := &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "repo"}, Worktree: wt}
+
+	tests := []struct {
+		name          string
+		value         string
+		expectedState State
+	}{
+		{"new name starts rename", "feature-2", StateRenamingWorktree},
+		{"unchanged name returns to dashboard", "feature", StateDashboard},
+		{"invalid name shows error", "", StateError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				state:            StateRenameWorktreeInput,
+				selectedInstance: inst,
+				worktreeInput:    newTextInput(""),
+			}
+			m.worktreeInput.SetValue(tt.value)
+			newModel, _ := m.handleRenameWorktreeInputKey(tea.KeyMsg{Type: tea.KeyEnter})
+			if model := newModel.(Model); model.state != tt.expectedState {
+				t.Errorf("state = %v, want %v", model.state, tt.expectedState)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Worktree integration tests
+// ============================================================================
+
+func TestHandleIntegrateOptionsKey(t *testing.T) {
+	tests := []struct {
+		name                string
+		key                 string
+		expectedState       State
+		expectedMode        devcontainer.IntegrateMode
+		expectedFastForward bool
+	}{
+		{"r rebases", "r", StateIntegrating, devcontainer.IntegrateRebase, true},
+		{"m merges", "m", StateIntegrating, devcontainer.IntegrateMerge, true},
+		{"f toggles fast-forward", "f", StateIntegrateOptions, devcontainer.IntegrateRebase, false},
+		{"n cancels", "n", StateDashboard, devcontainer.IntegrateRebase, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{state: StateIntegrateOptions, integrateFastForward: true}
+			newModel, _ := m.handleIntegrateOptionsKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			model := newModel.(Model)
+
+			if model.state != tt.expectedState {
+				t.Errorf("state = %v, want %v", model.state, tt.expectedState)
+			}
+			if model.integrateMode != tt.expectedMode {
+				t.Errorf("integrateMode = %v, want %v", model.integrateMode, tt.expectedMode)
+			}
+			if model.integrateFastForward != tt.expectedFastForward {
+				t.Errorf("integrateFastForward = %v, want %v", model.integrateFastForward, tt.expectedFastForward)
+			}
+		})
+	}
+}
+
+func TestHandleIntegrateResultKey(t *testing.T) {
+	conflicted := &devcontainer.IntegrateResult{Branch: "feature", Conflicts: []string{"a.go"}}
+
+	m := Model{state: StateIntegrateResult, integrateResult: conflicted}
+	newModel, cmd := m.handleIntegrateResultKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if model := newModel.(Model); model.state != StateIntegrating || cmd == nil {
+		t.Errorf("a with conflicts: state = %v, want %v with abort command", model.state, StateIntegrating)
+	}
+
+	m = Model{state: StateIntegrateResult, integrateResult: conflicted}
+	newModel, _ = m.handleIntegrateResultKey(tea.KeyMsg{Type: tea.KeyEsc})
+	model := newModel.(Model)
+	if model.state != StateDiscovering || !strings.Contains(model.warning, "unresolved conflicts") {
+		t.Errorf("esc with conflicts: state = %v, warning = %q", model.state, model.warning)
+	}
+}
+
+// ============================================================================
+// tmux session template tests
+// ============================================================================
+
+func TestHandleNewSessionInputKey_CyclesTemplates(t *testing.T) {
+	window := tmux.Template{Windows: []tmux.WindowTemplate{{}}}
+	cfg := &config.Config{TmuxTemplates: map[string]tmux.Template{"agent": window, "review": window}}
+
+	m := Model{state: StateNewSessionInput, config: cfg, textInput: newTextInput("")}
+	var got []string
+	for range 4 {
+		newModel, _ := m.handleNewSessionInputKey(tea.KeyMsg{Type: tea.KeyTab})
+		m = newModel.(Model)
+		got = append(got, m.sessionTemplate)
+	}
+	if want := []string{"agent", "review", "", "agent"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("tab cycled through %q, want %q", got, want)
+	}
+
+	newModel, _ := m.handleNewSessionInputKey(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if model := newModel.(Model); model.sessionTemplate != "" {
+		t.Errorf("shift+tab from agent = %q, want single window", model.sessionTemplate)
+	}
+}
+
+// ============================================================================
+// Agent notification tests
+// ============================================================================
+
+func TestHandleDashboardKey_AcknowledgesAttention(t *testing.T) {
+	m := Model{
+		state:     StateDashboard,
+		attention: map[string]bool{"/src/app": true},
+		instancesStatus: []devcontainer.ContainerInstanceWithStatus{{
+			ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+			Status:            devcontainer.StatusRunning,
+		}},
+	}
+	newModel, _ := m.handleDashboardKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if newModel.(Model).attention["/src/app"] {
+		t.Error("connecting to an instance should clear its attention highlight")
+	}
+}
+
+// ============================================================================
+// Send keys tests
+// ============================================================================
+
+func TestHandleBroadcastSelectKey(t *testing.T) {
+	targets := []sendTarget{
+		{session: "a", state: tmux.AgentWaiting},
+		{session: "b", state: tmux.AgentWorking},
+		{session: "c", state: tmux.AgentWaiting},
+	}
+
+	tests := []struct {
+		name             string
+		keys             []string
+		expectedState    State
+		expectedSelected int
+	}{
+		{"a selects all", []string{"a"}, StateBroadcastSelect, 3},
+		{"w selects waiting agents", []string{"a", "w"}, StateBroadcastSelect, 2},
+		{"space toggles current", []string{" "}, StateBroadcastSelect, 0},
+		{"enter asks for text", []string{"enter"}, StateSendKeysInput, 1},
+		{"enter ignored with nothing selected", []string{" ", "enter"}, StateBroadcastSelect, 0},
+		{"esc returns to dashboard", []string{"esc"}, StateDashboard, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{
+				state:        StateBroadcastSelect,
+				sendTargets:  targets,
+				sendSelected: map[int]bool{0: true},
+				sendInput:    newTextInput(""),
+			}
+			for _, key := range tt.keys {
+				var msg tea.KeyMsg
+				switch key {
+				case "enter":
+					msg = tea.KeyMsg{Type: tea.KeyEnter}
+				case "esc":
+					msg = tea.KeyMsg{Type: tea.KeyEsc}
+				case " ":
+					msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+				default:
+					msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+				}
+				newModel, _ := m.handleBroadcastSelectKey(msg)
+				m = newModel.(Model)
+			}
+
+			if m.state != tt.expectedState {
+				t.Errorf("state = %v, want %v", m.state, tt.expectedState)
+			}
+			if got := countSelected(m.sendSelected); got != tt.expectedSelected {
+				t.Errorf("selected = %d, want %d", got, tt.expectedSelected)
+			}
+		})
+	}
+}
+
+func TestHandleTmuxSelectKey_SendKeys(t *testing.T) {
+	instance := &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}}
+	m := Model{
+		state:            StateTmuxSelect,
+		selectedInstance: instance,
+		tmuxSessions:     []tmux.Session{{Name: "main"}},
+		sendInput:        newTextInput(""),
+	}
+
+	newModel, _ := m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = newModel.(Model)
+	if m.state != StateSendKeysInput || m.sendBroadcast {
+		t.Fatalf("state = %v, broadcast = %v, want single-session send input", m.state, m.sendBroadcast)
+	}
+	if got := m.selectedSendTargets(); len(got) != 1 || got[0] != "app / main" {
+		t.Errorf("selectedSendTargets() = %v, want [app / main]", got)
+	}
+
+	newModel, _ = m.handleSendKeysInputKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if state := newModel.(Model).state; state != StateTmuxSelect {
+		t.Errorf("esc should return to the session picker, got %v", state)
+	}
+
+	// "s" on the new session option does nothing
+	m = Model{state: StateTmuxSelect, selectedInstance: instance, tmuxSessions: []tmux.Session{{Name: "main"}}, cursor: 1}
+	newModel, _ = m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if state := newModel.(Model).state; state != StateTmuxSelect {
+		t.Errorf("state = %v, want StateTmuxSelect", state)
+	}
+}
+
+// ============================================================================
+// Tmux Window Management Tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_Windows(t *testing.T) {
+	instance := &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}}
+	newModel := func(cursor int) Model {
+		return Model{
+			state:            StateTmuxSelect,
+			selectedInstance: instance,
+			tmuxSessions:     windowedSessions(),
+			cursor:           cursor,
+			tmuxNameInput:    newTextInput(""),
+		}
+	}
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	tests := []struct {
+		name       string
+		cursor     int
+		key        string
+		wantState  State
+		wantAction tmuxNameAction
+		wantInput  string
+		wantWindow bool
+	}{
+		{"rename session", 0, "e", StateTmuxNameInput, renameSession, "main", false},
+		{"rename window", 2, "e", StateTmuxNameInput, renameWindow, "shell", true},
+		{"new window", 1, "w", StateTmuxNameInput, newWindow, "", false},
+		{"kill window", 2, "x", StateConfirmTmuxStop, renameSession, "", true},
+		{"kill session", 0, "x", StateConfirmTmuxStop, renameSession, "", false},
+		{"move window", 1, "m", StateTmuxMoveWindow, renameSession, "", true},
+		{"move needs a window row", 0, "m", StateTmuxSelect, renameSession, "", false},
+		{"rename on new session option", 4, "e", StateTmuxSelect, renameSession, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := newModel(tt.cursor).handleTmuxSelectKey(key(tt.key))
+			m := result.(Model)
+			if m.state != tt.wantState {
+				t.Fatalf("state = %v, want %v", m.state, tt.wantState)
+			}
+			if m.tmuxNameAction != tt.wantAction {
+				t.Errorf("tmuxNameAction = %v, want %v", m.tmuxNameAction, tt.wantAction)
+			}
+			if got := m.tmuxNameInput.Value(); got != tt.wantInput {
+				t.Errorf("name input = %q, want %q", got, tt.wantInput)
+			}
+			if (m.selectedWindow != nil) != tt.wantWindow {
+				t.Errorf("selectedWindow = %v, want window selected = %v", m.selectedWindow, tt.wantWindow)
+			}
+		})
+	}
+
+	// Moving is only possible when there is another session
+	m := newModel(1)
+	m.tmuxSessions = m.tmuxSessions[:1]
+	result, _ := m.handleTmuxSelectKey(key("m"))
+	if state := result.(Model).state; state != StateTmuxSelect {
+		t.Errorf("move with a single session: state = %v, want StateTmuxSelect", state)
+	}
+}
+
+func TestHandleTmuxMoveWindowKey(t *testing.T) {
+	sessions := windowedSessions()
+	m := Model{
+		state:            StateTmuxMoveWindow,
+		selectedInstance: &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app"}},
+		tmuxSessions:     sessions,
+		selectedSession:  &sessions[0],
+		selectedWindow:   &sessions[0].Windows[1],
+	}
+	if got := m.moveDestinations(); len(got) != 1 || got[0] != "logs" {
+		t.Fatalf("moveDestinations() = %v, want [logs]", got)
+	}
+
+	result, cmd := m.handleTmuxMoveWindowKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if state := result.(Model).state; state != StateTmuxUpdating || cmd == nil {
+		t.Errorf("enter: state = %v, want StateTmuxUpdating with a command", state)
+	}
+
+	result, _ = m.handleTmuxMoveWindowKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := result.(Model); got.state != StateTmuxSelect || got.selectedWindow != nil {
+		t.Errorf("esc: state = %v, window = %v, want picker with no window", got.state, got.selectedWindow)
+	}
+}
+
+func TestHandleTmuxNameInputKey_EmptyRename(t *testing.T) {
+	sessions := windowedSessions()
+	m := Model{
+		state:           StateTmuxNameInput,
+		tmuxSessions:    sessions,
+		selectedSession: &sessions[0],
+		tmuxNameAction:  renameSession,
+		tmuxNameInput:   newTextInput(""),
+	}
+	result, _ := m.handleTmuxNameInputKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if state := result.(Model).state; state != StateTmuxNameInput {
+		t.Errorf("empty rename: state = %v, want StateTmuxNameInput", state)
+	}
+
+	// A new window may be left unnamed
+	m.tmuxNameAction = newWindow
+	result, _ = m.handleTmuxNameInputKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if state := result.(Model).state; state != StateTmuxUpdating {
+		t.Errorf("unnamed new window: state = %v, want StateTmuxUpdating", state)
+	}
+}
+
+// ============================================================================
+// Scrollback Export Tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_ExportScrollback(t *testing.T) {
+	m := Model{
+		state:            StateTmuxSelect,
+		selectedInstance: &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app"}},
+		tmuxSessions:     windowedSessions(),
+		cursor:           2, // Window row: the whole session is exported
+	}
+	result, cmd := m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = result.(Model)
+	if m.state != StateExportingScrollback || cmd == nil {
+		t.Fatalf("state = %v, want StateExportingScrollback with a command", m.state)
+	}
+	if m.getSessionName() != "main" || m.selectedWindow != nil {
+		t.Errorf("selected = %q / %v, want session main", m.getSessionName(), m.selectedWindow)
+	}
+
+	result, _ = m.Update(scrollbackExportedMsg{paths: []string{"/t/app/20260101-000000-main.log"}})
+	m = result.(Model)
+	if m.state != StateScrollbackExported {
+		t.Fatalf("state = %v, want StateScrollbackExported", m.state)
+	}
+	if view := m.View(); !strings.Contains(view, "20260101-000000-main.log") {
+		t.Error("export result should list the written file")
+	}
+
+	result, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = result.(Model); m.state != StateTmuxSelect || m.exportedPaths != nil {
+		t.Errorf("any key: state = %v, paths = %v, want picker", m.state, m.exportedPaths)
+	}
+}
+
+// ============================================================================
+// Observe Mode Tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_Observe(t *testing.T) {
+	m := Model{
+		state:            StateTmuxSelect,
+		selectedInstance: &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+		tmuxSessions:     windowedSessions(),
+	}
+	result, cmd := m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = result.(Model)
+	if m.state != StateAttaching || !m.observing || cmd == nil {
+		t.Fatalf("state = %v, observing = %v, want a read-only attach", m.state, m.observing)
+	}
+	if view := m.View(); !strings.Contains(view, "Observing (read-only)") {
+		t.Errorf("observe view should be labelled read-only, got %q", view)
+	}
+
+	result, _ = m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = result.(Model); m.observing {
+		t.Error("a regular attach should not be read-only")
+	}
+}
@@ -1,6 +1,15 @@
 package tui
 
-import "strings"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/notify"
+)
 
 // renderSpinnerAction renders a spinner with an action message
 // Format: [spinner] [action] [name (optional)]...
@@ -30,3 +39,86 @@ func renderSpinnerWithHint(spinnerView, action, name, hint string) string {
 	}
 	return b.String()
 }
+
+// formatAge renders the time elapsed since t in a compact form (e.g. "3d ago")
+func formatAge(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// countSelected returns the number of true entries in a selection set
+func countSelected(selected map[int]bool) int {
+	n := 0
+	for _, v := range selected {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+// joinWarnings combines the non-empty warnings into a single message
+func joinWarnings(warnings ...string) string {
+	var nonEmpty []string
+	for _, w := range warnings {
+		if w != "" {
+			nonEmpty = append(nonEmpty, w)
+		}
+	}
+	return strings.Join(nonEmpty, "; ")
+}
+
+// sameInstances returns true if both status lists cover the same instances in the same order
+func sameInstances(a, b []devcontainer.ContainerInstanceWithStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Path != b[i].Path {
+			return false
+		}
+	}
+	return true
+}
+
+// eventText describes an agent transition in a notification
+func eventText(event string) string {
+	if event == notify.EventWaiting {
+		return "is waiting for input"
+	}
+	return "has finished"
+}
+
+// rendererSafeWriter collects what a notification writes to the terminal the
+// program renders to, and sends it with a single Write on Flush. The renderer
+// draws each frame with a single Write to the same file and os.File serializes
+// Writes, so an escape sequence written from a command's goroutine never lands
+// in the middle of a frame.
+type rendererSafeWriter struct {
+	out io.Writer
+	buf bytes.Buffer
+}
+
+// Write buffers p until Flush
+func (w *rendererSafeWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Flush writes everything buffered in one Write
+func (w *rendererSafeWriter) Flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.out.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
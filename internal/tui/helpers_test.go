@@ -3,8 +3,10 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/notify"
 	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
@@ -318,457 +320,73 @@ func TestRenderSpinnerWithHint_NoHint(t *testing.T) {
 	}
 }
 
-// ============================================================================
-// styles.go tests
-// ============================================================================
-
-func TestRepeatChar(t *testing.T) {
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name     string
-		char     string
-		n        int
+		t        time.Time
 		expected string
 	}{
-		{"zero count", "x", 0, ""},
-		{"negative count", "x", -5, ""},
-		{"one char", "x", 1, "x"},
-		{"multiple chars", "-", 5, "-----"},
-		{"unicode char", "─", 3, "───"},
-		{"multi-char string", "ab", 3, "ababab"},
+		{"seconds", now.Add(-30 * time.Second), "just now"},
+		{"minutes", now.Add(-5 * time.Minute), "5m ago"},
+		{"hours", now.Add(-3 * time.Hour), "3h ago"},
+		{"days", now.Add(-72 * time.Hour), "3d ago"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := repeatChar(tt.char, tt.n)
-			if result != tt.expected {
-				t.Errorf("repeatChar(%q, %d) = %q, want %q", tt.char, tt.n, result, tt.expected)
+			if got := formatAge(tt.t, now); got != tt.expected {
+				t.Errorf("formatAge() = %q, want %q", got, tt.expected)
 			}
 		})
 	}
 }
 
-func TestCursor(t *testing.T) {
-	result := Cursor()
-
-	// Should contain the cursor symbol
-	if !strings.Contains(result, "›") {
-		t.Errorf("Cursor() = %q, should contain '›'", result)
-	}
-
-	// Should have consistent length (styled, so may have ANSI codes)
-	if len(result) == 0 {
-		t.Error("Cursor() should not return empty string")
-	}
+// writeRecorder records every Write it receives
+type writeRecorder struct {
+	writes []string
 }
 
-func TestNoCursor(t *testing.T) {
-	result := NoCursor()
-
-	// Should be exactly 2 spaces
-	if result != "  " {
-		t.Errorf("NoCursor() = %q, want %q", result, "  ")
-	}
-
-	// Length should match Cursor's visual width (2 chars)
-	if len(result) != 2 {
-		t.Errorf("NoCursor() length = %d, want 2", len(result))
-	}
+func (r *writeRecorder) Write(p []byte) (int, error) {
+	r.writes = append(r.writes, string(p))
+	return len(p), nil
 }
 
-func TestRenderSeparator(t *testing.T) {
-	tests := []struct {
-		name  string
-		width int
-	}{
-		{"zero width uses default", 0},
-		{"negative width uses default", -10},
-		{"small width", 10},
-		{"medium width", 40},
-		{"large width", 80},
+func TestRendererSafeWriter(t *testing.T) {
+	out := &writeRecorder{}
+	term := &rendererSafeWriter{out: out}
+	if err := notify.Send(term, notify.MethodOSC777, "claude-quick", "app: waiting"); err != nil {
+		t.Fatal(err)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := RenderSeparator(tt.width)
-
-			// Should contain the separator character
-			if !strings.Contains(result, "─") {
-				t.Errorf("RenderSeparator(%d) should contain '─'", tt.width)
-			}
-
-			// Should not be empty
-			if len(result) == 0 {
-				t.Errorf("RenderSeparator(%d) should not be empty", tt.width)
-			}
-		})
-	}
-}
-
-func TestRenderKeyBinding(t *testing.T) {
-	tests := []struct {
-		name        string
-		key         string
-		description string
-	}{
-		{"simple key", "q", "quit"},
-		{"arrow key", "↑↓", "navigate"},
-		{"modifier key", "ctrl+c", "cancel"},
-		{"enter key", "enter", "select"},
+	term.Write([]byte("\a"))
+	if len(out.writes) != 0 {
+		t.Fatalf("writes before Flush = %q, want none", out.writes)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := RenderKeyBinding(tt.key, tt.description)
-
-			// Should contain both key and description
-			if !strings.Contains(result, tt.key) {
-				t.Errorf("RenderKeyBinding(%q, %q) should contain key", tt.key, tt.description)
-			}
-			if !strings.Contains(result, tt.description) {
-				t.Errorf("RenderKeyBinding(%q, %q) should contain description", tt.key, tt.description)
-			}
-		})
-	}
-}
-
-func TestRenderBorderedHeader(t *testing.T) {
-	tests := []struct {
-		name     string
-		title    string
-		subtitle string
-		width    int
-	}{
-		{"basic header", "Title", "Subtitle", 60},
-		{"no subtitle", "Title", "", 60},
-		{"zero width uses default", "Title", "Sub", 0},
-		{"narrow width", "Title", "Subtitle", 30},
-		{"wide width", "Title", "Subtitle", 100},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := RenderBorderedHeader(tt.title, tt.subtitle, tt.width)
-
-			// Should contain title
-			if !strings.Contains(result, tt.title) {
-				t.Errorf("RenderBorderedHeader should contain title %q", tt.title)
-			}
-
-			// Should contain subtitle if provided
-			if tt.subtitle != "" && !strings.Contains(result, tt.subtitle) {
-				t.Errorf("RenderBorderedHeader should contain subtitle %q", tt.subtitle)
-			}
-
-			// Should contain border characters
-			if !strings.Contains(result, "┌") || !strings.Contains(result, "┐") {
-				t.Error("RenderBorderedHeader should contain top border corners")
-			}
-			if !strings.Contains(result, "└") || !strings.Contains(result, "┘") {
-				t.Error("RenderBorderedHeader should contain bottom border corners")
-			}
-			if !strings.Contains(result, "│") {
-				t.Error("RenderBorderedHeader should contain side borders")
-			}
-		})
+	if err := term.Flush(); err != nil {
+		t.Fatal(err)
 	}
-}
-
-func TestGetStatusIndicator(t *testing.T) {
-	tests := []struct {
-		name     string
-		running  bool
-		unknown  bool
-		contains string
-	}{
-		{"running", true, false, "running"},
-		{"stopped", false, false, "stopped"},
-		{"unknown", false, true, "unknown"},
-		{"unknown takes precedence", true, true, "unknown"},
+	want := "\x1b]777;notify;claude-quick;app: waiting\a\a"
+	if len(out.writes) != 1 || out.writes[0] != want {
+		t.Errorf("writes = %q, want the whole notification in one write %q", out.writes, want)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := GetStatusIndicator(tt.running, tt.unknown)
-
-			if !strings.Contains(result, tt.contains) {
-				t.Errorf("GetStatusIndicator(%v, %v) = %q, should contain %q",
-					tt.running, tt.unknown, result, tt.contains)
-			}
-		})
+	// Nothing buffered, nothing written
+	if err := term.Flush(); err != nil || len(out.writes) != 1 {
+		t.Errorf("second Flush() wrote %q, err %v; want nothing", out.writes[1:], err)
 	}
 }
 
-// ============================================================================
-// container.go tests
-// ============================================================================
-
-func TestRenderSimpleHeader(t *testing.T) {
-	tests := []struct {
-		name     string
-		subtitle string
-	}{
-		{"with subtitle", "Container Dashboard"},
-		{"empty subtitle", ""},
+func TestSameInstances(t *testing.T) {
+	status := func(path string) devcontainer.ContainerInstanceWithStatus {
+		return devcontainer.ContainerInstanceWithStatus{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Path: path}}}
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := renderSimpleHeader(tt.subtitle)
-			content := result.String()
-
-			// Should contain the app title
-			if !strings.Contains(content, "claude-quick") {
-				t.Error("renderSimpleHeader should contain 'claude-quick'")
-			}
-
-			// Should contain subtitle if provided
-			if tt.subtitle != "" && !strings.Contains(content, tt.subtitle) {
-				t.Errorf("renderSimpleHeader should contain subtitle %q", tt.subtitle)
-			}
-		})
-	}
-}
-
-func TestRenderWithHeader(t *testing.T) {
-	tests := []struct {
-		name     string
-		subtitle string
-	}{
-		{"with subtitle", "Container Dashboard"},
-		{"empty subtitle", ""},
+	a := []devcontainer.ContainerInstanceWithStatus{status("/a"), status("/b")}
+	if !sameInstances(a, []devcontainer.ContainerInstanceWithStatus{status("/a"), status("/b")}) {
+		t.Error("same paths should match")
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := renderWithHeader(tt.subtitle)
-			content := result.String()
-
-			// Should contain the app title
-			if !strings.Contains(content, "claude-quick") {
-				t.Error("renderWithHeader should contain 'claude-quick'")
-			}
-
-			// Should contain border characters (from RenderBorderedHeader)
-			if !strings.Contains(content, "┌") {
-				t.Error("renderWithHeader should contain bordered header")
-			}
-		})
+	if sameInstances(a, []devcontainer.ContainerInstanceWithStatus{status("/a")}) {
+		t.Error("different lengths should not match")
 	}
-}
-
-func TestRenderConfirmDialog(t *testing.T) {
-	tests := []struct {
-		name       string
-		operation  string
-		entityType string
-		labelType  string
-		entityName string
-		contains   []string
-	}{
-		{
-			name:       "stop container",
-			operation:  "stop",
-			entityType: "container",
-			labelType:  "Project",
-			entityName: "myproject",
-			contains:   []string{"Stop", "container", "Project", "myproject", "Confirm", "Cancel"},
-		},
-		{
-			name:       "restart container",
-			operation:  "restart",
-			entityType: "container",
-			labelType:  "Project",
-			entityName: "myapp",
-			contains:   []string{"Restart", "container", "Project", "myapp"},
-		},
-		{
-			name:       "stop tmux session",
-			operation:  "stop",
-			entityType: "tmux session",
-			labelType:  "Session",
-			entityName: "main",
-			contains:   []string{"Stop", "tmux session", "Session", "main"},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := renderConfirmDialog(tt.operation, tt.entityType, tt.labelType, tt.entityName)
-
-			for _, expected := range tt.contains {
-				if !strings.Contains(result, expected) {
-					t.Errorf("renderConfirmDialog should contain %q", expected)
-				}
-			}
-		})
-	}
-}
-
-func TestRenderOperation(t *testing.T) {
-	tests := []struct {
-		name       string
-		operation  string
-		entityType string
-		entityName string
-		spinner    string
-	}{
-		{"with entity type", "Stopping", "session", "main", "⠋"},
-		{"without entity type", "Stopping", "", "myproject", "⠋"},
-		{"restart operation", "Restarting", "", "myapp", "⠙"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := renderOperation(tt.operation, tt.entityType, tt.entityName, tt.spinner)
-
-			// Should contain operation
-			if !strings.Contains(result, tt.operation) {
-				t.Errorf("renderOperation should contain operation %q", tt.operation)
-			}
-
-			// Should contain entity name
-			if !strings.Contains(result, tt.entityName) {
-				t.Errorf("renderOperation should contain entity name %q", tt.entityName)
-			}
-
-			// Should contain entity type if provided
-			if tt.entityType != "" && !strings.Contains(result, tt.entityType) {
-				t.Errorf("renderOperation should contain entity type %q", tt.entityType)
-			}
-
-			// Should contain spinner
-			if !strings.Contains(result, tt.spinner) {
-				t.Errorf("renderOperation should contain spinner %q", tt.spinner)
-			}
-
-			// Should end with ...
-			if !strings.HasSuffix(result, "...") {
-				t.Error("renderOperation should end with ...")
-			}
-		})
-	}
-}
-
-// ============================================================================
-// model.go tests
-// ============================================================================
-
-func TestModel_GetInstanceName(t *testing.T) {
-	tests := []struct {
-		name     string
-		model    Model
-		expected string
-	}{
-		{
-			name:     "nil instance",
-			model:    Model{selectedInstance: nil},
-			expected: "",
-		},
-		{
-			name: "valid instance",
-			model: Model{
-				selectedInstance: &devcontainer.ContainerInstance{
-					Project: devcontainer.Project{Name: "myproject", Path: "/path/to/project"},
-				},
-			},
-			expected: "myproject",
-		},
-		{
-			name: "instance with worktree",
-			model: Model{
-				selectedInstance: &devcontainer.ContainerInstance{
-					Project: devcontainer.Project{Name: "myproject", Path: "/path/to/project"},
-					Worktree: &devcontainer.WorktreeInfo{
-						Branch: "feature/auth",
-						IsMain: false,
-					},
-				},
-			},
-			expected: "myproject [feature/auth]",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := tt.model.getInstanceName()
-			if result != tt.expected {
-				t.Errorf("getInstanceName() = %q, want %q", result, tt.expected)
-			}
-		})
-	}
-}
-
-func TestModel_GetSessionName(t *testing.T) {
-	tests := []struct {
-		name     string
-		model    Model
-		expected string
-	}{
-		{
-			name:     "nil session",
-			model:    Model{selectedSession: nil},
-			expected: "",
-		},
-		{
-			name: "valid session",
-			model: Model{
-				selectedSession: &tmux.Session{Name: "main"},
-			},
-			expected: "main",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := tt.model.getSessionName()
-			if result != tt.expected {
-				t.Errorf("getSessionName() = %q, want %q", result, tt.expected)
-			}
-		})
-	}
-}
-
-func TestModel_GetWorktreeBranch(t *testing.T) {
-	tests := []struct {
-		name     string
-		model    Model
-		expected string
-	}{
-		{
-			name:     "nil instance",
-			model:    Model{selectedInstance: nil},
-			expected: "",
-		},
-		{
-			name: "instance without worktree",
-			model: Model{
-				selectedInstance: &devcontainer.ContainerInstance{
-					Project:  devcontainer.Project{Name: "myproject"},
-					Worktree: nil,
-				},
-			},
-			expected: "",
-		},
-		{
-			name: "instance with worktree",
-			model: Model{
-				selectedInstance: &devcontainer.ContainerInstance{
-					Project: devcontainer.Project{Name: "myproject"},
-					Worktree: &devcontainer.WorktreeInfo{
-						Branch: "feature/auth",
-					},
-				},
-			},
-			expected: "feature/auth",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := tt.model.getWorktreeBranch()
-			if result != tt.expected {
-				t.Errorf("getWorktreeBranch() = %q, want %q", result, tt.expected)
-			}
-		})
+	if sameInstances(a, []devcontainer.ContainerInstanceWithStatus{status("/b"), status("/a")}) {
+		t.Error("different order should not match")
 	}
 }
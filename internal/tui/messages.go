@@ -3,6 +3,7 @@ package tui
 import (
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 // Message types for async operations in Bubbletea.
@@ -11,6 +12,7 @@ import (
 // instancesDiscoveredMsg is sent when project discovery completes
 type instancesDiscoveredMsg struct {
 	instances []devcontainer.ContainerInstance
+	warning   string // Legacy credential files that git tracks or doesn't ignore (empty if none)
 }
 
 // instanceStatusRefreshedMsg is sent when container status refresh completes
@@ -28,16 +30,47 @@ type containerStartedMsg struct {
 type containerErrorMsg struct{ err error }
 
 // tmuxSessionsLoadedMsg is sent when tmux session list is loaded
-type tmuxSessionsLoadedMsg struct{ sessions []string }
+type tmuxSessionsLoadedMsg struct {
+	sessions []string
+	windows  []string                    // Windows of all sessions, in tmux.WindowListFormat
+	saved    *devcontainer.SavedSessions // Sessions saved before the container stopped, if none are running
+}
+
+// sessionsRestoredMsg is sent when saved sessions were recreated
+type sessionsRestoredMsg struct{ errors []string }
+
+// tmuxWindowsChangedMsg is sent when a session or window was renamed, created or moved
+type tmuxWindowsChangedMsg struct{}
+
+// panesCapturedMsg is sent when the active pane of every session has been captured
+type panesCapturedMsg struct{ panes map[string]tmux.Pane }
+
+// agentPollTickMsg triggers a background inspection of all instances
+type agentPollTickMsg struct{}
+
+// agentsPolledMsg is sent when the background inspection of all instances finishes
+type agentsPolledMsg struct {
+	statuses []devcontainer.ContainerInstanceWithStatus
+}
+
+// panePreviewTickMsg triggers a refresh of the pane preview
+type panePreviewTickMsg struct{ gen int }
 
 // tmuxSessionCreatedMsg is sent when a new tmux session is created
 type tmuxSessionCreatedMsg struct{}
 
 // containerStoppedMsg is sent when a container is stopped
-type containerStoppedMsg struct{}
+type containerStoppedMsg struct {
+	warning string // Set if the sessions' scrollback or layout could not be saved
+}
 
 // containerRestartedMsg is sent when a container is restarted
-type containerRestartedMsg struct{}
+type containerRestartedMsg struct {
+	warning string // Set if the sessions' scrollback or layout could not be saved
+}
+
+// scrollbackExportedMsg is sent when a session's scrollback was written to the host
+type scrollbackExportedMsg struct{ paths []string }
 
 // tmuxSessionStoppedMsg is sent when a tmux session is killed
 type tmuxSessionStoppedMsg struct{}
@@ -45,17 +78,82 @@ type tmuxSessionStoppedMsg struct{}
 // tmuxSessionRestartedMsg is sent when a tmux session is restarted
 type tmuxSessionRestartedMsg struct{}
 
+// containerExitsWatchedMsg is sent once docker is being followed for stopped devcontainers
+type containerExitsWatchedMsg struct {
+	exits <-chan devcontainer.ContainerExit
+}
+
+// containerExitedMsg is sent when a devcontainer stops, inside or outside claude-quick
+type containerExitedMsg struct {
+	exit  devcontainer.ContainerExit        // The stopped container
+	exits <-chan devcontainer.ContainerExit // Where the next stopped container comes from
+}
+
 // tmuxDetachedMsg is sent when user detaches from tmux
 type tmuxDetachedMsg struct{}
 
+// hostTmuxOpenedMsg is sent when an attach was opened in (or switched to) a host tmux window or pane
+type hostTmuxOpenedMsg struct{}
+
 // worktreeCreatedMsg is sent when a new git worktree is created
 type worktreeCreatedMsg struct {
-	worktreePath string
-	pushWarning  string
+	worktreePath     string
+	createWarning    string // Warning if push, sparse-checkout or submodule update failed
+	bootstrapWarning string // Warning if copying files or post-create commands failed
+}
+
+// worktreeLockChangedMsg is sent when a worktree has been locked or unlocked
+type worktreeLockChangedMsg struct {
+	locked bool
+}
+
+// worktreeRenamedMsg is sent when a worktree has been renamed and moved
+type worktreeRenamedMsg struct {
+	worktreePath string // New worktree directory
+	restart      bool   // True if the container was running before the move
+}
+
+// integrateDoneMsg is sent when a worktree integration finishes or stops on conflicts
+type integrateDoneMsg struct {
+	result devcontainer.IntegrateResult
+}
+
+// integrateAbortedMsg is sent when a stopped rebase/merge has been aborted
+type integrateAbortedMsg struct{}
+
+// worktreeStatusLoadedMsg is sent when a worktree has been checked for unsaved work
+type worktreeStatusLoadedMsg struct {
+	status devcontainer.WorktreeStatus
+	err    error // Non-nil if the status could not be determined
 }
 
 // worktreeDeletedMsg is sent when a git worktree is deleted
-type worktreeDeletedMsg struct{}
+type worktreeDeletedMsg struct {
+	warning string // Non-fatal problems (e.g. remote branch deletion failed)
+}
+
+// cleanupCandidatesLoadedMsg is sent when the scan for finished worktrees completes
+type cleanupCandidatesLoadedMsg struct {
+	candidates []devcontainer.CleanupCandidate
+	warning    string // Non-fatal problems (e.g. GitHub checks unavailable)
+}
+
+// cleanupDoneMsg is sent when bulk worktree cleanup finishes
+type cleanupDoneMsg struct {
+	removed int      // Number of worktrees removed
+	errors  []string // Per-worktree failures
+}
+
+// broadcastTargetsLoadedMsg is sent when the sessions of all instances have been listed
+type broadcastTargetsLoadedMsg struct {
+	statuses []devcontainer.ContainerInstanceWithStatus
+}
+
+// keysSentMsg is sent when text has been typed into the chosen sessions
+type keysSentMsg struct {
+	sent   int      // Number of sessions the text was sent to
+	errors []string // Per-container failures
+}
 
 // githubIssuesLoadedMsg is sent when GitHub issues are successfully fetched
 type githubIssuesLoadedMsg struct {
@@ -76,10 +174,11 @@ type githubIssueDetailLoadedMsg struct {
 
 // githubWorktreeCreatedMsg is sent when worktree creation from issue succeeds
 type githubWorktreeCreatedMsg struct {
-	worktreePath string
-	branchName   string
-	pushWarning  string
-	labelWarning string // Warning if label addition failed
+	worktreePath     string
+	branchName       string
+	createWarning    string // Warning if push, sparse-checkout or submodule update failed
+	labelWarning     string // Warning if label addition failed
+	bootstrapWarning string // Warning if copying files or post-create commands failed
 }
 
 // tmuxNotFoundError indicates tmux is not available in the container
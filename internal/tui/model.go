@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -14,6 +17,7 @@ import (
 	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/notify"
 	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
@@ -38,13 +42,59 @@ type Model struct {
 	warning          string // Warning message (auth, push failures, etc.)
 	darkMode         bool   // Current theme mode (true = dark, false = light)
 
+	// Pane state (tmux session picker)
+	panes      map[string]tmux.Pane // Active pane of each session, for previews and agent state
+	previewGen int                  // Refresh loop generation; ticks from older loops are dropped
+
+	// Background work that lives as long as the program (the docker events watcher)
+	ctx    context.Context    // Cancelled by Close
+	cancel context.CancelFunc // Stops the background work
+
+	// Agent monitoring state
+	agentTracker *notify.Tracker // Last agent state of every session, for notifications
+	agentPolling bool            // Whether the background poll loop is running
+	attention    map[string]bool // Instance paths with an agent needing attention
+
+	// Send keys state
+	sendTargets   []sendTarget    // Sessions the text can be sent to
+	sendSelected  map[int]bool    // Target indexes the text will be sent to
+	sendBroadcast bool            // Targets span all containers (from the dashboard)
+	sendInput     textinput.Model // Text to type into the sessions
+
+	// New session state
+	sessionTemplate string // Template for the session being created ("" for a single window)
+
+	// Session and window management state
+	selectedWindow *tmux.Window    // Window to kill, rename or move (nil for the whole session)
+	tmuxNameAction tmuxNameAction  // What the typed name is used for
+	tmuxNameInput  textinput.Model // Name for a renamed session/window or a new window
+	moveCursor     int             // Destination session cursor when moving a window
+	exportedPaths  []string        // Transcript files written by the last scrollback export
+	observing      bool            // The current attach is read-only
+
+	// Session restore state
+	savedSessions *devcontainer.SavedSessions // Sessions saved before the container stopped
+
+	// Worktree deletion state
+	worktreeStatus     *devcontainer.WorktreeStatus // Unsaved work in the worktree (nil if unknown)
+	worktreeDeleteMode worktreeDeleteMode           // Option chosen in the delete confirmation
+
+	// Worktree integration state
+	integrateMode        devcontainer.IntegrateMode    // Rebase or merge
+	integrateFastForward bool                          // Fast-forward the base branch afterwards
+	integrateResult      *devcontainer.IntegrateResult // Outcome of the last integration
+
+	// Bulk cleanup state
+	cleanupCandidates []devcontainer.CleanupCandidate // Finished worktrees found by the scan
+	cleanupSelected   map[int]bool                    // Candidate indexes selected for removal
+
 	// GitHub Issues state
 	githubIssues    []github.Issue  // Cached list of issues
 	selectedIssue   *github.Issue   // Currently selected issue
 	githubRepoOwner string          // Detected owner (e.g., "christophergyman")
 	githubRepoName  string          // Detected repo name (e.g., "claude-quick")
 
-	// Auto-start state (for GitHub issue worktree creation)
+	// Auto-start state (for GitHub issue worktrees and renamed worktrees)
 	pendingAutoStart      bool   // Whether to auto-start after discovery
 	autoStartWorktreePath string // Path of newly created worktree to auto-start
 
@@ -81,12 +131,71 @@ func (m Model) getSessionName() string {
 	return m.selectedSession.Name
 }
 
-// getWorktreeBranch safely returns the selected worktree's branch name
+// refreshAfterContainerOperation returns to the dashboard and refreshes container status
+func (m Model) refreshAfterContainerOperation() (tea.Model, tea.Cmd) {
+	m.state = StateRefreshingStatus
+	m.selectedInstance = nil
+	return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
+}
+
+// moveDestinations returns the sessions the selected window can be moved to
+func (m Model) moveDestinations() []string {
+	var names []string
+	for _, session := range m.tmuxSessions {
+		if session.Name != m.getSessionName() {
+			names = append(names, session.Name)
+		}
+	}
+	return names
+}
+
+// renderTmuxNameInput renders the name input for the current tmuxNameAction
+func (m Model) renderTmuxNameInput() string {
+	switch m.tmuxNameAction {
+	case renameWindow:
+		target := m.getSessionName()
+		if m.selectedWindow != nil {
+			target += " " + m.selectedWindow.FormatWindow()
+		}
+		return RenderTmuxNameInput("Rename Window", "Window", target, "", m.tmuxNameInput)
+	case newWindow:
+		return RenderTmuxNameInput("New Window", "Session", m.getSessionName(),
+			"Leave empty to name the window after its command", m.tmuxNameInput)
+	default:
+		return RenderTmuxNameInput("Rename Session", "Session", m.getSessionName(), "", m.tmuxNameInput)
+	}
+}
+
+// getWorktreeBranch safely returns the selected worktree's branch name (short SHA if detached)
 func (m Model) getWorktreeBranch() string {
 	if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
 		return ""
 	}
-	return m.selectedInstance.Worktree.Branch
+	return m.selectedInstance.Worktree.BranchLabel()
+}
+
+// nextSessionTemplate returns the template name step positions away from the
+// current one, cycling through "" (single window) and the configured templates
+func (m Model) nextSessionTemplate(step int) string {
+	if m.config == nil {
+		return ""
+	}
+	options := append([]string{""}, m.config.TmuxTemplateNames()...)
+	current := 0
+	for i, name := range options {
+		if name == m.sessionTemplate {
+			current = i
+		}
+	}
+	return options[((current+step)%len(options)+len(options))%len(options)]
+}
+
+// worktreeConfig returns the worktree settings for the selected project
+func (m Model) worktreeConfig() devcontainer.WorktreeConfig {
+	if m.config == nil || m.selectedInstance == nil {
+		return devcontainer.WorktreeConfig{}
+	}
+	return m.config.WorktreeConfigFor(m.selectedInstance.Name)
 }
 
 // newTextInput creates a configured text input with the given placeholder
@@ -114,6 +223,8 @@ func New(instances []devcontainer.ContainerInstance, cfg *config.Config) Model {
 		spinner:       s,
 		textInput:     newTextInput(cfg.DefaultSessionName),
 		worktreeInput: newTextInput(constants.DefaultWorktreePlaceholder),
+		sendInput:     newTextInput(constants.SendKeysPlaceholder),
+		tmuxNameInput: newTextInput(constants.TmuxNamePlaceholder),
 		config:        cfg,
 		darkMode:      darkMode,
 	}
@@ -129,14 +240,27 @@ func NewWithDiscovery(cfg *config.Config) Model {
 	s.Spinner = spinner.Dot
 	s.Style = SpinnerStyle
 
+	ctx, cancel := context.WithCancel(context.Background())
 	return Model{
 		state:         StateDiscovering,
 		instances:     nil,
 		spinner:       s,
 		textInput:     newTextInput(cfg.DefaultSessionName),
 		worktreeInput: newTextInput(constants.DefaultWorktreePlaceholder),
+		sendInput:     newTextInput(constants.SendKeysPlaceholder),
+		tmuxNameInput: newTextInput(constants.TmuxNamePlaceholder),
 		config:        cfg,
 		darkMode:      darkMode,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Close stops the model's background work, such as the docker events watcher.
+// Call it once the program has exited.
+func (m Model) Close() {
+	if m.cancel != nil {
+		m.cancel()
 	}
 }
 
@@ -156,6 +280,8 @@ func NewWithWizard(cfg *config.Config) Model {
 		spinner:       s,
 		textInput:     newTextInput(cfg.DefaultSessionName),
 		worktreeInput: newTextInput(constants.DefaultWorktreePlaceholder),
+		sendInput:     newTextInput(constants.SendKeysPlaceholder),
+		tmuxNameInput: newTextInput(constants.TmuxNamePlaceholder),
 		config:        cfg,
 		darkMode:      darkMode,
 	}
@@ -166,6 +292,66 @@ func NewWithWizard(cfg *config.Config) Model {
 	return m
 }
 
+// startAgentPolling starts the background loop that inspects all instances, which
+// keeps the dashboard's agent states current and raises notifications
+func (m *Model) startAgentPolling() tea.Cmd {
+	if m.agentPolling {
+		return nil
+	}
+	m.agentPolling = true
+	return scheduleAgentPoll()
+}
+
+// observeAgents feeds the agent state of every session to the tracker. Instances
+// with a transition their project notifies on are marked for attention, and a
+// command delivering one notification for all of them is returned.
+func (m *Model) observeAgents(statuses []devcontainer.ContainerInstanceWithStatus) tea.Cmd {
+	if m.agentTracker == nil {
+		m.agentTracker = notify.NewTracker()
+	}
+	var alerts []string
+	for _, instance := range statuses {
+		for session, state := range instance.AgentStates {
+			event := m.agentTracker.Observe(instance.Path+"\x00"+session, state)
+			if event == "" || !m.config.NotifiesOn(instance.Name, event) {
+				continue
+			}
+			if m.attention == nil {
+				m.attention = make(map[string]bool)
+			}
+			m.attention[instance.Path] = true
+			alerts = append(alerts, fmt.Sprintf("%s/%s %s", instance.DisplayName(), session, eventText(event)))
+		}
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	sort.Strings(alerts)
+	message := alerts[0]
+	if len(alerts) > 1 {
+		message = fmt.Sprintf("%d agents need attention: %s", len(alerts), strings.Join(alerts, ", "))
+	}
+	return sendNotification(m.config.NotifyMethod, message)
+}
+
+// selectedSendTargets returns the labels of the sessions the text will be sent to
+func (m Model) selectedSendTargets() []string {
+	var labels []string
+	for i, target := range m.sendTargets {
+		if m.sendSelected[i] {
+			labels = append(labels, target.label())
+		}
+	}
+	return labels
+}
+
+// startPanePreview starts a new pane preview refresh loop, ending any previous one
+func (m *Model) startPanePreview() tea.Cmd {
+	m.previewGen++
+	return tea.Batch(m.capturePanes(), schedulePanePreview(m.previewGen))
+}
+
 // initWizardState initializes wizard fields from a config
 func (m *Model) initWizardState(cfg *config.Config) {
 	// Initialize wizard inputs
@@ -198,7 +384,7 @@ func (m *Model) initWizardState(cfg *config.Config) {
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
 	if m.state == StateDiscovering {
-		return tea.Batch(m.spinner.Tick, m.discoverInstances())
+		return tea.Batch(m.spinner.Tick, m.discoverInstances(), m.sweepCredentials(), m.watchContainerExits())
 	}
 	return nil
 }
@@ -221,12 +407,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case instancesDiscoveredMsg:
 		m.instances = msg.instances
+		if !strings.Contains(m.warning, msg.warning) {
+			m.warning = joinWarnings(m.warning, msg.warning)
+		}
 		m.state = StateRefreshingStatus
 		m.cursor = 0
 		return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
 
 	case instanceStatusRefreshedMsg:
 		m.instancesStatus = msg.statuses
+		agentCmds := tea.Batch(m.observeAgents(msg.statuses), m.startAgentPolling())
 
 		// Check if we need to auto-start a newly created worktree
 		if m.pendingAutoStart && m.autoStartWorktreePath != "" {
@@ -239,7 +429,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.autoStartWorktreePath = ""
 					// Start the container
 					m.state = StateContainerStarting
-					return m, tea.Batch(m.spinner.Tick, m.startContainer())
+					return m, tea.Batch(m.spinner.Tick, m.startContainer(), agentCmds)
 				}
 			}
 			// If not found, clear and go to dashboard
@@ -247,7 +437,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.state = StateDashboard
-		return m, nil
+		return m, agentCmds
+
+	case agentPollTickMsg:
+		return m, m.pollAgents()
+
+	case agentsPolledMsg:
+		// Keep the dashboard live, unless instances were added or removed meanwhile
+		if m.state == StateDashboard && sameInstances(m.instancesStatus, msg.statuses) {
+			m.instancesStatus = msg.statuses
+		}
+		return m, tea.Batch(m.observeAgents(msg.statuses), scheduleAgentPoll())
+
+	case containerExitsWatchedMsg:
+		return m, waitForContainerExit(msg.exits)
+
+	case containerExitedMsg:
+		return m, tea.Batch(cleanupExitedCredentials(msg.exit), waitForContainerExit(msg.exits))
 
 	case tmuxDetachedMsg:
 		// User detached from tmux, return to dashboard with status refresh
@@ -267,42 +473,161 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tmuxSessionsLoadedMsg:
-		m.tmuxSessions = tmux.ParseSessions(msg.sessions)
-		m.state = StateTmuxSelect
+		m.tmuxSessions = tmux.AddWindows(tmux.ParseSessions(msg.sessions), msg.windows)
 		m.cursor = 0
+		if msg.saved != nil {
+			// The container was stopped with sessions running, offer to bring them back
+			m.savedSessions = msg.saved
+			m.state = StateConfirmRestoreSessions
+			return m, nil
+		}
+		m.state = StateTmuxSelect
+		return m, m.startPanePreview()
+
+	case panesCapturedMsg:
+		m.panes = msg.panes
 		return m, nil
 
+	case panePreviewTickMsg:
+		// Stale ticks end their loop; the loop also ends once the picker is left
+		if msg.gen != m.previewGen || m.state != StateTmuxSelect {
+			return m, nil
+		}
+		return m, tea.Batch(m.capturePanes(), schedulePanePreview(msg.gen))
+
 	case tmuxSessionCreatedMsg:
 		// Session created, now attach
 		sessionName := m.textInput.Value()
 		return m.attachToSession(sessionName)
 
-	case containerStoppedMsg, containerRestartedMsg:
-		// Refresh status after container operation
-		m.state = StateRefreshingStatus
-		m.selectedInstance = nil
-		return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
+	case containerStoppedMsg:
+		m.warning = msg.warning
+		return m.refreshAfterContainerOperation()
+
+	case containerRestartedMsg:
+		m.warning = msg.warning
+		return m.refreshAfterContainerOperation()
+
+	case sessionsRestoredMsg:
+		m.savedSessions = nil
+		if len(msg.errors) > 0 {
+			m.warning = "Some sessions were not restored: " + strings.Join(msg.errors, "; ")
+		}
+		m.state = StateLoadingTmuxSessions
+		return m, tea.Batch(m.spinner.Tick, m.loadTmuxSessions())
+
+	case scrollbackExportedMsg:
+		m.exportedPaths = msg.paths
+		m.state = StateScrollbackExported
+		return m, nil
 
-	case tmuxSessionStoppedMsg, tmuxSessionRestartedMsg:
-		// Reload tmux sessions after stop/restart with loading animation
+	case tmuxSessionStoppedMsg, tmuxSessionRestartedMsg, tmuxWindowsChangedMsg, hostTmuxOpenedMsg:
+		// Reload tmux sessions after stop/restart/rename/move (or attaching in a
+		// host tmux window, which leaves claude-quick running) with loading animation
 		m.selectedSession = nil
+		m.selectedWindow = nil
 		m.cursor = 0
 		m.state = StateLoadingTmuxSessions
 		return m, tea.Batch(m.spinner.Tick, m.loadTmuxSessions())
 
 	case worktreeCreatedMsg:
-		// Store push warning for display (clear any previous warning)
-		m.warning = msg.pushWarning
+		// Store warnings for display (clear any previous warning)
+		m.warning = joinWarnings(msg.createWarning, msg.bootstrapWarning)
 		// Worktree created, refresh instances
 		m.state = StateDiscovering
 		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
 
+	case worktreeLockChangedMsg:
+		// Lock state changed, refresh instances
+		m.state = StateDiscovering
+		m.selectedInstance = nil
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
+	case worktreeRenamedMsg:
+		// Container is path-based, so a running one is recreated at the new path
+		if msg.restart {
+			m.pendingAutoStart = true
+			m.autoStartWorktreePath = msg.worktreePath
+		}
+		m.state = StateDiscovering
+		m.selectedInstance = nil
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
+	case integrateDoneMsg:
+		result := msg.result
+		m.integrateResult = &result
+		m.state = StateIntegrateResult
+		return m, nil
+
+	case integrateAbortedMsg:
+		m.integrateResult = nil
+		m.state = StateDiscovering
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
+	case worktreeStatusLoadedMsg:
+		m.worktreeStatus = nil
+		if msg.err == nil {
+			status := msg.status
+			m.worktreeStatus = &status
+		}
+		m.state = StateConfirmDeleteWorktree
+		return m, nil
+
 	case worktreeDeletedMsg:
 		// Worktree deleted, refresh instances
+		m.warning = msg.warning
 		m.state = StateDiscovering
 		m.selectedInstance = nil
+		m.worktreeStatus = nil
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
+	case cleanupCandidatesLoadedMsg:
+		m.cleanupCandidates = msg.candidates
+		m.warning = msg.warning
+		// Nothing is selected up front, the user picks what to remove
+		m.cleanupSelected = make(map[int]bool, len(msg.candidates))
+		m.cursor = 0
+		m.state = StateCleanupList
+		return m, nil
+
+	case cleanupDoneMsg:
+		m.cleanupCandidates = nil
+		m.cleanupSelected = nil
+		m.warning = ""
+		if len(msg.errors) > 0 {
+			m.warning = fmt.Sprintf("Removed %d worktree(s), %d failed: %s",
+				msg.removed, len(msg.errors), strings.Join(msg.errors, "; "))
+		}
+		m.cursor = 0
+		m.state = StateDiscovering
 		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
 
+	case broadcastTargetsLoadedMsg:
+		m.sendTargets = broadcastTargets(msg.statuses)
+		// Preselect agents waiting for input, the usual reason to broadcast
+		m.sendSelected = make(map[int]bool, len(m.sendTargets))
+		for i, target := range m.sendTargets {
+			m.sendSelected[i] = target.state == tmux.AgentWaiting
+		}
+		m.cursor = 0
+		m.state = StateBroadcastSelect
+		return m, nil
+
+	case keysSentMsg:
+		m.warning = ""
+		if len(msg.errors) > 0 {
+			m.warning = fmt.Sprintf("Sent to %d session(s), some failed: %s", msg.sent, strings.Join(msg.errors, "; "))
+		}
+		m.sendTargets = nil
+		m.sendSelected = nil
+		if m.sendBroadcast {
+			m.cursor = 0
+			m.state = StateRefreshingStatus
+			return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
+		}
+		m.state = StateLoadingTmuxSessions
+		return m, tea.Batch(m.spinner.Tick, m.loadTmuxSessions())
+
 	case githubIssuesLoadedMsg:
 		m.githubIssues = msg.issues
 		m.githubRepoOwner = msg.owner
@@ -331,15 +656,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.selectedIssue = nil
 
 		// Combine warnings for display
-		var warnings []string
-		if msg.pushWarning != "" {
-			warnings = append(warnings, msg.pushWarning)
-		}
-		if msg.labelWarning != "" {
-			warnings = append(warnings, msg.labelWarning)
-		}
-		if len(warnings) > 0 {
-			m.warning = strings.Join(warnings, "; ")
+		if warning := joinWarnings(msg.createWarning, msg.labelWarning, msg.bootstrapWarning); warning != "" {
+			m.warning = warning
 		}
 
 		// Set up auto-start for after discovery completes
@@ -403,7 +721,7 @@ func (m Model) View() string {
 		return RenderRefreshingStatus(m.spinner.View())
 
 	case StateDashboard:
-		return RenderDashboard(m.instancesStatus, m.cursor, m.width, m.warning)
+		return RenderDashboard(m.instancesStatus, m.cursor, m.width, m.warning, m.attention)
 
 	case StateContainerStarting:
 		return RenderContainerStarting(m.getInstanceName(), m.spinner.View())
@@ -421,6 +739,9 @@ func (m Model) View() string {
 		return RenderContainerOperation("Restarting", m.getInstanceName(), m.spinner.View())
 
 	case StateConfirmTmuxStop:
+		if m.selectedWindow != nil {
+			return RenderTmuxWindowConfirmDialog(m.getSessionName(), *m.selectedWindow)
+		}
 		return RenderTmuxConfirmDialog("stop", m.getSessionName())
 
 	case StateConfirmTmuxRestart:
@@ -432,21 +753,45 @@ func (m Model) View() string {
 	case StateTmuxRestarting:
 		return RenderTmuxOperation("Restarting", m.getSessionName(), m.spinner.View())
 
+	case StateTmuxNameInput:
+		return m.renderTmuxNameInput()
+
+	case StateTmuxMoveWindow:
+		if m.selectedWindow == nil {
+			return ""
+		}
+		return RenderTmuxMoveWindow(m.getSessionName(), *m.selectedWindow, m.moveDestinations(), m.moveCursor)
+
+	case StateTmuxUpdating:
+		return RenderTmuxUpdating(m.getSessionName(), m.spinner.View())
+
+	case StateExportingScrollback:
+		return RenderTmuxOperation("Exporting", m.getSessionName(), m.spinner.View())
+
+	case StateScrollbackExported:
+		return RenderScrollbackExported(m.getSessionName(), m.exportedPaths)
+
+	case StateConfirmRestoreSessions:
+		return RenderRestoreSessions(m.getInstanceName(), m.savedSessions, time.Now())
+
+	case StateRestoringSessions:
+		return RenderRestoringSessions(m.getInstanceName(), m.spinner.View())
+
 	case StateLoadingTmuxSessions:
 		return RenderLoadingTmuxSessions(m.getInstanceName(), m.spinner.View())
 
 	case StateTmuxSelect:
-		return RenderTmuxSelect(m.getInstanceName(), m.tmuxSessions, m.cursor, m.warning)
+		return RenderTmuxSelect(m.getInstanceName(), m.tmuxSessions, m.cursor, m.warning, m.panes, m.width)
 
 	case StateNewSessionInput:
-		return RenderNewSessionInput(m.getInstanceName(), m.textInput)
+		return RenderNewSessionInput(m.getInstanceName(), m.textInput, m.sessionTemplate, m.config != nil && len(m.config.TmuxTemplates) > 0)
 
 	case StateAttaching:
 		sessionName := m.textInput.Value()
-		if m.cursor < len(m.tmuxSessions) {
-			sessionName = m.tmuxSessions[m.cursor].Name
+		if session, _ := tmuxRowAt(m.tmuxSessions, m.cursor); session != nil {
+			sessionName = session.Name
 		}
-		return RenderAttaching(m.getInstanceName(), sessionName, m.spinner.View())
+		return RenderAttaching(m.getInstanceName(), sessionName, m.spinner.View(), m.observing)
 
 	case StateNewWorktreeInput:
 		projectName := ""
@@ -458,12 +803,51 @@ func (m Model) View() string {
 	case StateCreatingWorktree:
 		return RenderCreatingWorktree(m.worktreeInput.Value(), m.spinner.View())
 
+	case StateLoadingWorktreeStatus:
+		return RenderLoadingWorktreeStatus(m.getWorktreeBranch(), m.spinner.View())
+
 	case StateConfirmDeleteWorktree:
-		return RenderConfirmDeleteWorktree(m.getWorktreeBranch())
+		return RenderConfirmDeleteWorktree(m.getWorktreeBranch(), m.worktreeStatus)
 
 	case StateDeletingWorktree:
 		return RenderDeletingWorktree(m.getWorktreeBranch(), m.spinner.View())
 
+	case StateRenameWorktreeInput:
+		return RenderRenameWorktreeInput(m.getInstanceName(), m.worktreeInput)
+
+	case StateRenamingWorktree:
+		return RenderRenamingWorktree(m.worktreeInput.Value(), m.spinner.View())
+
+	case StateIntegrateOptions:
+		return RenderIntegrateOptions(m.getWorktreeBranch(), m.integrateFastForward)
+
+	case StateIntegrating:
+		return RenderIntegrating(m.getWorktreeBranch(), m.integrateMode, m.spinner.View())
+
+	case StateIntegrateResult:
+		return RenderIntegrateResult(m.integrateResult, m.integrateMode)
+
+	case StateCleanupScanning:
+		return RenderCleanupScanning(m.spinner.View())
+
+	case StateCleanupList:
+		return RenderCleanupList(m.cleanupCandidates, m.cleanupSelected, m.cursor, m.warning, m.width)
+
+	case StateCleaningUp:
+		return RenderCleaningUp(countSelected(m.cleanupSelected), m.spinner.View())
+
+	case StateBroadcastLoading:
+		return RenderBroadcastLoading(m.spinner.View())
+
+	case StateBroadcastSelect:
+		return RenderBroadcastSelect(m.sendTargets, m.sendSelected, m.cursor, m.width)
+
+	case StateSendKeysInput:
+		return RenderSendKeysInput(m.selectedSendTargets(), m.sendInput)
+
+	case StateSendingKeys:
+		return RenderSendingKeys(len(m.selectedSendTargets()), m.spinner.View())
+
 	case StateError:
 		return RenderError(m.err, m.errHint)
 
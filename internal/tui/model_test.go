@@ -0,0 +1,337 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+func TestModel_GetInstanceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    Model
+		expected string
+	}{
+		{
+			name:     "nil instance",
+			model:    Model{selectedInstance: nil},
+			expected: "",
+		},
+		{
+			name: "valid instance",
+			model: Model{
+				selectedInstance: &devcontainer.ContainerInstance{
+					Project: devcontainer.Project{Name: "myproject", Path: "/path/to/project"},
+				},
+			},
+			expected: "myproject",
+		},
+		{
+			name: "instance with worktree",
+			model: Model{
+				selectedInstance: &devcontainer.ContainerInstance{
+					Project: devcontainer.Project{Name: "myproject", Path: "/path/to/project"},
+					Worktree: &devcontainer.WorktreeInfo{
+						Branch: "feature/auth",
+						IsMain: false,
+					},
+				},
+			},
+			expected: "myproject [feature/auth]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.model.getInstanceName()
+			if result != tt.expected {
+				t.Errorf("getInstanceName() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModel_GetSessionName(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    Model
+		expected string
+	}{
+		{
+			name:     "nil session",
+			model:    Model{selectedSession: nil},
+			expected: "",
+		},
+		{
+			name: "valid session",
+			model: Model{
+				selectedSession: &tmux.Session{Name: "main"},
+			},
+			expected: "main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.model.getSessionName()
+			if result != tt.expected {
+				t.Errorf("getSessionName() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestModel_GetWorktreeBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    Model
+		expected string
+	}{
+		{
+			name:     "nil instance",
+			model:    Model{selectedInstance: nil},
+			expected: "",
+		},
+		{
+			name: "instance without worktree",
+			model: Model{
+				selectedInstance: &devcontainer.ContainerInstance{
+					Project:  devcontainer.Project{Name: "myproject"},
+					Worktree: nil,
+				},
+			},
+			expected: "",
+		},
+		{
+			name: "instance with worktree",
+			model: Model{
+				selectedInstance: &devcontainer.ContainerInstance{
+					Project: devcontainer.Project{Name: "myproject"},
+					Worktree: &devcontainer.WorktreeInfo{
+						Branch: "feature/auth",
+					},
+				},
+			},
+			expected: "feature/auth",
+		},
+		{
+			name: "instance with detached worktree",
+			model: Model{
+				selectedInstance: &devcontainer.ContainerInstance{
+					Project: devcontainer.Project{Name: "myproject"},
+					Worktree: &devcontainer.WorktreeInfo{
+						Detached: true,
+						Head:     "0123456789abcdef",
+					},
+				},
+			},
+			expected: "0123456",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.model.getWorktreeBranch()
+			if result != tt.expected {
+				t.Errorf("getWorktreeBranch() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Bulk cleanup tests
+// ============================================================================
+
+func TestModel_CleanupCandidatesLoaded(t *testing.T) {
+	m := Model{state: StateCleanupScanning}
+	result, _ := m.Update(cleanupCandidatesLoadedMsg{candidates: make([]devcontainer.CleanupCandidate, 2)})
+	got := result.(Model)
+	if got.state != StateCleanupList {
+		t.Errorf("state = %v, want StateCleanupList", got.state)
+	}
+	if n := countSelected(got.cleanupSelected); n != 0 {
+		t.Errorf("%d candidate(s) selected up front, want none", n)
+	}
+}
+
+// ============================================================================
+// Pane preview tests
+// ============================================================================
+
+func TestModel_PanePreviewTick(t *testing.T) {
+	m := Model{
+		state:            StateTmuxSelect,
+		selectedInstance: &devcontainer.ContainerInstance{},
+		tmuxSessions:     []tmux.Session{{Name: "main"}},
+		previewGen:       2,
+	}
+
+	if _, cmd := m.Update(panePreviewTickMsg{gen: 1}); cmd != nil {
+		t.Error("tick from an older refresh loop should be dropped")
+	}
+	if _, cmd := m.Update(panePreviewTickMsg{gen: 2}); cmd == nil {
+		t.Error("current tick should schedule a refresh")
+	}
+	m.state = StateDashboard
+	if _, cmd := m.Update(panePreviewTickMsg{gen: 2}); cmd != nil {
+		t.Error("refresh loop should end once the picker is left")
+	}
+}
+
+// ============================================================================
+// Agent notification tests
+// ============================================================================
+
+func TestModel_ObserveAgents(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.NotifyMethod = "none"
+	cfg.Projects = map[string]config.ProjectConfig{"docs": {Notify: []string{}}}
+	m := Model{config: cfg}
+
+	statuses := func(app, docs tmux.AgentState) []devcontainer.ContainerInstanceWithStatus {
+		return []devcontainer.ContainerInstanceWithStatus{
+			{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+				AgentStates: map[string]tmux.AgentState{"main": app}},
+			{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "docs", Path: "/src/docs"}},
+				AgentStates: map[string]tmux.AgentState{"main": docs}},
+		}
+	}
+
+	if cmd := m.observeAgents(statuses(tmux.AgentWorking, tmux.AgentWorking)); cmd != nil || len(m.attention) != 0 {
+		t.Error("first observation should only set the baseline")
+	}
+	if cmd := m.observeAgents(statuses(tmux.AgentWaiting, tmux.AgentWaiting)); cmd == nil {
+		t.Error("agent starting to wait should notify")
+	}
+	if !m.attention["/src/app"] || m.attention["/src/docs"] {
+		t.Errorf("attention = %v, want only /src/app (docs disables notifications)", m.attention)
+	}
+	if cmd := m.observeAgents(statuses(tmux.AgentWaiting, tmux.AgentWaiting)); cmd != nil {
+		t.Error("agent still waiting should not notify again")
+	}
+}
+
+// ============================================================================
+// Session Restore Tests
+// ============================================================================
+
+func TestModel_OffersSavedSessions(t *testing.T) {
+	saved := &devcontainer.SavedSessions{
+		Path:    "/src/app",
+		SavedAt: time.Now().Add(-2 * time.Hour),
+		Sessions: []tmux.SavedSession{{Name: "main", Windows: []tmux.WindowTemplate{
+			{Panes: []tmux.PaneTemplate{{Launch: true}, {Command: "npm test"}}},
+		}}},
+	}
+	m := Model{
+		state:            StateLoadingTmuxSessions,
+		selectedInstance: &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+	}
+	result, _ := m.Update(tmuxSessionsLoadedMsg{sessions: []string{}, saved: saved})
+	m = result.(Model)
+	if m.state != StateConfirmRestoreSessions {
+		t.Fatalf("state = %v, want StateConfirmRestoreSessions", m.state)
+	}
+	view := m.View()
+	for _, want := range []string{"1 session(s)", "2h ago", "main  1 window(s), 2 pane(s), 1 agent(s)", "continue conversations"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("restore prompt should contain %q", want)
+		}
+	}
+
+	tests := []struct {
+		key       tea.KeyMsg
+		wantState State
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, StateRestoringSessions},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}, StateRestoringSessions},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, StateLoadingTmuxSessions},
+		{tea.KeyMsg{Type: tea.KeyEsc}, StateTmuxSelect},
+	}
+	for _, tt := range tests {
+		result, _ := m.handleRestoreSessionsKey(tt.key)
+		if state := result.(Model).state; state != tt.wantState {
+			t.Errorf("key %q: state = %v, want %v", tt.key.String(), state, tt.wantState)
+		}
+	}
+
+	// Running sessions are shown without an offer
+	m.state = StateLoadingTmuxSessions
+	result, _ = m.Update(tmuxSessionsLoadedMsg{sessions: []string{"main:0"}})
+	if state := result.(Model).state; state != StateTmuxSelect {
+		t.Errorf("state = %v, want StateTmuxSelect", state)
+	}
+}
+
+func TestModel_SessionsRestored(t *testing.T) {
+	m := Model{
+		state:            StateRestoringSessions,
+		selectedInstance: &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+		savedSessions:    &devcontainer.SavedSessions{},
+	}
+	result, cmd := m.Update(sessionsRestoredMsg{errors: []string{"logs: failed"}})
+	m = result.(Model)
+	if m.state != StateLoadingTmuxSessions || cmd == nil || m.savedSessions != nil {
+		t.Errorf("state = %v, saved = %v, want reload with the saved sessions cleared", m.state, m.savedSessions)
+	}
+	if !strings.Contains(m.warning, "logs: failed") {
+		t.Errorf("warning = %q, want the failed session", m.warning)
+	}
+}
+
+// ============================================================================
+// Credential cleanup tests
+// ============================================================================
+
+func TestModel_ContainerExited(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	// A docker that reports no running containers
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "docker"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+	path := "/src/app"
+	if err := auth.WriteCredentialFile(path, map[string]string{"TOKEN": "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	exits := make(chan devcontainer.ContainerExit, 1)
+	m := Model{state: StateDashboard}
+	result, cmd := m.Update(containerExitsWatchedMsg{exits: exits})
+	if cmd == nil || result.(Model).state != StateDashboard {
+		t.Fatal("containerExitsWatchedMsg did not start waiting for exits")
+	}
+	exits <- devcontainer.ContainerExit{Path: path, Time: time.Now()}
+	msg := cmd()
+	exited, ok := msg.(containerExitedMsg)
+	if !ok || exited.exit.Path != path {
+		t.Fatalf("waiting for exits returned %#v, want containerExitedMsg for %s", msg, path)
+	}
+
+	_, cmd = m.Update(exited)
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("containerExitedMsg returned %#v, want cleanup and the next wait", cmd())
+	}
+	batch[0]()
+	if _, err := os.Stat(auth.CredentialFilePath(path)); !os.IsNotExist(err) {
+		t.Error("credentials of the stopped container were not removed")
+	}
+
+	// Waiting ends quietly once docker events exits
+	close(exits)
+	if msg := batch[1](); msg != nil {
+		t.Errorf("waiting after docker events exited returned %#v, want nil", msg)
+	}
+}
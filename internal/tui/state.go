@@ -36,6 +36,20 @@ const (
 	StateTmuxStopping
 	// StateTmuxRestarting is shown while a tmux session is being restarted
 	StateTmuxRestarting
+	// StateTmuxNameInput shows text input for a session or window name (rename, new window)
+	StateTmuxNameInput
+	// StateTmuxMoveWindow lets the user pick the session to move a window to
+	StateTmuxMoveWindow
+	// StateTmuxUpdating is shown while renaming, creating or moving tmux sessions and windows
+	StateTmuxUpdating
+	// StateExportingScrollback is shown while a session's scrollback is written to the host
+	StateExportingScrollback
+	// StateScrollbackExported lists the transcript files written by an export
+	StateScrollbackExported
+	// StateConfirmRestoreSessions offers to recreate the sessions saved before the container stopped
+	StateConfirmRestoreSessions
+	// StateRestoringSessions is shown while saved sessions are recreated
+	StateRestoringSessions
 	// StateLoadingTmuxSessions is shown while loading tmux sessions from a container
 	StateLoadingTmuxSessions
 	// StateError displays an error message
@@ -46,10 +60,36 @@ const (
 	StateNewWorktreeInput
 	// StateCreatingWorktree is shown while creating a new git worktree
 	StateCreatingWorktree
+	// StateLoadingWorktreeStatus is shown while checking a worktree for unsaved work
+	StateLoadingWorktreeStatus
 	// StateConfirmDeleteWorktree prompts user to confirm deleting a worktree
 	StateConfirmDeleteWorktree
 	// StateDeletingWorktree is shown while deleting a git worktree
 	StateDeletingWorktree
+	// StateRenameWorktreeInput shows text input for a worktree's new branch name
+	StateRenameWorktreeInput
+	// StateRenamingWorktree is shown while renaming and moving a git worktree
+	StateRenamingWorktree
+	// StateIntegrateOptions lets the user choose how to integrate a worktree into its base branch
+	StateIntegrateOptions
+	// StateIntegrating is shown while rebasing/merging a worktree branch
+	StateIntegrating
+	// StateIntegrateResult shows the outcome of an integration, including any conflicts
+	StateIntegrateResult
+	// StateCleanupScanning is shown while looking for finished worktrees to clean up
+	StateCleanupScanning
+	// StateCleanupList lists finished worktrees and lets the user pick which to remove
+	StateCleanupList
+	// StateCleaningUp is shown while removing the selected worktrees
+	StateCleaningUp
+	// StateBroadcastLoading is shown while listing the sessions of all running containers
+	StateBroadcastLoading
+	// StateBroadcastSelect lets the user pick the sessions to broadcast text to
+	StateBroadcastSelect
+	// StateSendKeysInput shows text input for the text to type into the chosen sessions
+	StateSendKeysInput
+	// StateSendingKeys is shown while typing the text into the chosen sessions
+	StateSendingKeys
 	// StateGitHubIssuesLoading is shown while fetching issues from GitHub
 	StateGitHubIssuesLoading
 	// StateGitHubIssuesList displays the list of GitHub issues
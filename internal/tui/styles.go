@@ -82,6 +82,11 @@ var (
 	WarningStyle = lipgloss.NewStyle().
 			Foreground(currentPalette.warning)
 
+	// Attention style for dashboard rows with an agent needing attention
+	AttentionStyle = lipgloss.NewStyle().
+			Foreground(currentPalette.warning).
+			Bold(true)
+
 	// Box style for containers
 	BoxStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -158,6 +163,10 @@ func ApplyTheme(darkMode bool) {
 	WarningStyle = lipgloss.NewStyle().
 		Foreground(currentPalette.warning)
 
+	AttentionStyle = lipgloss.NewStyle().
+		Foreground(currentPalette.warning).
+		Bold(true)
+
 	BoxStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(currentPalette.dim).
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepeatChar(t *testing.T) {
+	tests := []struct {
+		name     string
+		char     string
+		n        int
+		expected string
+	}{
+		{"zero count", "x", 0, ""},
+		{"negative count", "x", -5, ""},
+		{"one char", "x", 1, "x"},
+		{"multiple chars", "-", 5, "-----"},
+		{"unicode char", "─", 3, "───"},
+		{"multi-char string", "ab", 3, "ababab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := repeatChar(tt.char, tt.n)
+			if result != tt.expected {
+				t.Errorf("repeatChar(%q, %d) = %q, want %q", tt.char, tt.n, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	result := Cursor()
+
+	// Should contain the cursor symbol
+	if !strings.Contains(result, "›") {
+		t.Errorf("Cursor() = %q, should contain '›'", result)
+	}
+
+	// Should have consistent length (styled, so may have ANSI codes)
+	if len(result) == 0 {
+		t.Error("Cursor() should not return empty string")
+	}
+}
+
+func TestNoCursor(t *testing.T) {
+	result := NoCursor()
+
+	// Should be exactly 2 spaces
+	if result != "  " {
+		t.Errorf("NoCursor() = %q, want %q", result, "  ")
+	}
+
+	// Length should match Cursor's visual width (2 chars)
+	if len(result) != 2 {
+		t.Errorf("NoCursor() length = %d, want 2", len(result))
+	}
+}
+
+func TestRenderSeparator(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+	}{
+		{"zero width uses default", 0},
+		{"negative width uses default", -10},
+		{"small width", 10},
+		{"medium width", 40},
+		{"large width", 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderSeparator(tt.width)
+
+			// Should contain the separator character
+			if !strings.Contains(result, "─") {
+				t.Errorf("RenderSeparator(%d) should contain '─'", tt.width)
+			}
+
+			// Should not be empty
+			if len(result) == 0 {
+				t.Errorf("RenderSeparator(%d) should not be empty", tt.width)
+			}
+		})
+	}
+}
+
+func TestRenderKeyBinding(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		description string
+	}{
+		{"simple key", "q", "quit"},
+		{"arrow key", "↑↓", "navigate"},
+		{"modifier key", "ctrl+c", "cancel"},
+		{"enter key", "enter", "select"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderKeyBinding(tt.key, tt.description)
+
+			// Should contain both key and description
+			if !strings.Contains(result, tt.key) {
+				t.Errorf("RenderKeyBinding(%q, %q) should contain key", tt.key, tt.description)
+			}
+			if !strings.Contains(result, tt.description) {
+				t.Errorf("RenderKeyBinding(%q, %q) should contain description", tt.key, tt.description)
+			}
+		})
+	}
+}
+
+func TestRenderBorderedHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		subtitle string
+		width    int
+	}{
+		{"basic header", "Title", "Subtitle", 60},
+		{"no subtitle", "Title", "", 60},
+		{"zero width uses default", "Title", "Sub", 0},
+		{"narrow width", "Title", "Subtitle", 30},
+		{"wide width", "Title", "Subtitle", 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderBorderedHeader(tt.title, tt.subtitle, tt.width)
+
+			// Should contain title
+			if !strings.Contains(result, tt.title) {
+				t.Errorf("RenderBorderedHeader should contain title %q", tt.title)
+			}
+
+			// Should contain subtitle if provided
+			if tt.subtitle != "" && !strings.Contains(result, tt.subtitle) {
+				t.Errorf("RenderBorderedHeader should contain subtitle %q", tt.subtitle)
+			}
+
+			// Should contain border characters
+			if !strings.Contains(result, "┌") || !strings.Contains(result, "┐") {
+				t.Error("RenderBorderedHeader should contain top border corners")
+			}
+			if !strings.Contains(result, "└") || !strings.Contains(result, "┘") {
+				t.Error("RenderBorderedHeader should contain bottom border corners")
+			}
+			if !strings.Contains(result, "│") {
+				t.Error("RenderBorderedHeader should contain side borders")
+			}
+		})
+	}
+}
+
+func TestGetStatusIndicator(t *testing.T) {
+	tests := []struct {
+		name     string
+		running  bool
+		unknown  bool
+		contains string
+	}{
+		{"running", true, false, "running"},
+		{"stopped", false, false, "stopped"},
+		{"unknown", false, true, "unknown"},
+		{"unknown takes precedence", true, true, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetStatusIndicator(tt.running, tt.unknown)
+
+			if !strings.Contains(result, tt.contains) {
+				t.Errorf("GetStatusIndicator(%v, %v) = %q, should contain %q",
+					tt.running, tt.unknown, result, tt.contains)
+			}
+		})
+	}
+}
@@ -3,16 +3,21 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 const newSessionOption = "[+ New Session]"
 
-// RenderTmuxSelect renders the tmux session selection view
-func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, warning string) string {
+// RenderTmuxSelect renders the tmux session selection view with the agent state of
+// each session. When the terminal is wide enough, the highlighted session's active
+// pane is previewed beside the list.
+func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, warning string, panes map[string]tmux.Pane, termWidth int) string {
 	width := defaultWidth
 
 	var b strings.Builder
@@ -36,21 +41,39 @@ func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, w
 	b.WriteString("  " + RenderSeparator(width-4))
 	b.WriteString("\n")
 
-	// Render sessions
-	for i, session := range sessions {
-		var line string
-		display := session.FormatSession()
-		if i == cursor {
-			line = Cursor() + SelectedStyle.Render(display)
+	// Render sessions, each followed by its windows
+	rows := tmuxRows(sessions)
+	for i, row := range rows {
+		session := sessions[row.session]
+		var display, badge string
+		if row.window < 0 {
+			display = session.FormatSession()
+			if pane, ok := panes[session.Name]; ok {
+				badge = " " + agentBadge(pane.AgentState(), 1)
+			}
 		} else {
-			line = NoCursor() + ItemStyle.Render(display)
+			branch := "├ "
+			if row.window == len(session.Windows)-1 {
+				branch = "└ "
+			}
+			display = "  " + branch + session.Windows[row.window].FormatWindow()
+		}
+
+		var line string
+		switch {
+		case i == cursor:
+			line = Cursor() + SelectedStyle.Render(display) + badge
+		case row.window >= 0:
+			line = NoCursor() + DimmedStyle.Render(display)
+		default:
+			line = NoCursor() + ItemStyle.Render(display) + badge
 		}
 		b.WriteString(line)
 		b.WriteString("\n")
 	}
 
 	// Render "New Session" option
-	newSessionIdx := len(sessions)
+	newSessionIdx := len(rows)
 	var newSessionLine string
 	if cursor == newSessionIdx {
 		newSessionLine = Cursor() + SelectedStyle.Render(newSessionOption)
@@ -66,16 +89,28 @@ func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, w
 	b.WriteString("\n")
 
 	// Key bindings - first row
-	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s",
+	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s  %s",
 		RenderKeyBinding("↑↓", "navigate"),
 		RenderKeyBinding("enter", "select"),
+		RenderKeyBinding("s", "send"),
 		RenderKeyBinding("x", "stop"),
 		RenderKeyBinding("r", "restart"),
 	)
 	b.WriteString(keybindings1)
 	b.WriteString("\n")
 
-	// Key bindings - second row with right-aligned detach hint
+	// Key bindings - second row (windows)
+	keybindings2 := fmt.Sprintf("  %s  %s  %s  %s  %s",
+		RenderKeyBinding("o", "observe"),
+		RenderKeyBinding("e", "rename"),
+		RenderKeyBinding("w", "new window"),
+		RenderKeyBinding("m", "move window"),
+		RenderKeyBinding("a", "export"),
+	)
+	b.WriteString(keybindings2)
+	b.WriteString("\n")
+
+	// Key bindings - third row with right-aligned detach hint
 	leftKeys := fmt.Sprintf("  %s  %s  %s",
 		RenderKeyBinding("t", "theme"),
 		RenderKeyBinding("?", "config"),
@@ -91,17 +126,61 @@ func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, w
 	}
 	b.WriteString(leftKeys + repeatChar(" ", footerSpacing) + rightKey)
 
+	if session, _ := tmuxRowAt(sessions, cursor); session != nil {
+		name := session.Name
+		if panel := renderPanePreview(name, panes[name].Lines, termWidth-width-2); panel != "" {
+			return lipgloss.JoinHorizontal(lipgloss.Top, b.String(), "  ", panel)
+		}
+	}
 	return b.String()
 }
 
-// RenderNewSessionInput renders the text input view for new session name
-func RenderNewSessionInput(projectName string, ti textinput.Model) string {
+// renderPanePreview renders the bordered preview of a session's active pane within
+// maxWidth columns, or "" if there is not enough room
+func renderPanePreview(session string, lines []string, maxWidth int) string {
+	panelWidth := min(maxWidth, constants.PanePreviewMaxWidth)
+	if panelWidth < constants.PanePreviewMinWidth {
+		return ""
+	}
+	innerWidth := panelWidth - 4 // Border and padding
+	clip := lipgloss.NewStyle().MaxWidth(innerWidth)
+
+	var b strings.Builder
+	b.WriteString(ColumnHeaderStyle.Render(clip.Render("PREVIEW: " + session)))
+	b.WriteString("\n")
+	b.WriteString(RenderSeparator(innerWidth))
+
+	// Pad to a fixed height so the panel does not jump as output changes
+	for i := 0; i < constants.PanePreviewLines; i++ {
+		b.WriteString("\n")
+		switch {
+		case i < len(lines):
+			b.WriteString(clip.Render(lines[i]))
+		case i == 0:
+			b.WriteString(DimmedStyle.Render("(no output)"))
+		}
+	}
+
+	return BoxStyle.Width(innerWidth + 2).Render(b.String())
+}
+
+// RenderNewSessionInput renders the text input view for new session name.
+// When templates are configured, the selected template is shown and can be cycled.
+func RenderNewSessionInput(projectName string, ti textinput.Model, template string, hasTemplates bool) string {
 	b := renderWithHeader("New Session: " + projectName)
 	b.WriteString("Enter session name:")
 	b.WriteString("\n\n")
 	b.WriteString(ti.View())
 	b.WriteString("\n\n")
 
+	if hasTemplates {
+		if template == "" {
+			template = "single window"
+		}
+		b.WriteString("Template: " + SelectedStyle.Render(template))
+		b.WriteString("\n\n")
+	}
+
 	// Footer
 	b.WriteString(RenderSeparator(defaultWidth - 4))
 	b.WriteString("\n")
@@ -109,13 +188,19 @@ func RenderNewSessionInput(projectName string, ti textinput.Model) string {
 		RenderKeyBinding("enter", "create"),
 		RenderKeyBinding("esc", "cancel"),
 	)
+	if hasTemplates {
+		keybindings += "  " + RenderKeyBinding("tab", "template")
+	}
 	b.WriteString(keybindings)
 
 	return b.String()
 }
 
 // RenderAttaching renders the view while attaching to a tmux session
-func RenderAttaching(projectName, sessionName, spinnerView string) string {
+func RenderAttaching(projectName, sessionName, spinnerView string, observe bool) string {
+	if observe {
+		return renderSpinnerAction(spinnerView, "Observing (read-only)", sessionName)
+	}
 	return renderSpinnerAction(spinnerView, "Attaching to", sessionName)
 }
 
@@ -127,14 +212,46 @@ func RenderLoadingTmuxSessions(projectName, spinnerView string) string {
 	return b.String()
 }
 
-// TotalTmuxOptions returns the total number of selectable options (sessions + new session)
+// tmuxRow is a row of the session picker: a session, or one of its windows
+type tmuxRow struct {
+	session int // Index into the sessions
+	window  int // Index into the session's windows, or -1 for the session itself
+}
+
+// tmuxRows returns the picker rows: each session followed by its windows
+func tmuxRows(sessions []tmux.Session) []tmuxRow {
+	var rows []tmuxRow
+	for i, session := range sessions {
+		rows = append(rows, tmuxRow{session: i, window: -1})
+		for j := range session.Windows {
+			rows = append(rows, tmuxRow{session: i, window: j})
+		}
+	}
+	return rows
+}
+
+// tmuxRowAt returns the session and window on a picker row. The window is nil on a
+// session row, and both are nil on the "New Session" option.
+func tmuxRowAt(sessions []tmux.Session, cursor int) (*tmux.Session, *tmux.Window) {
+	rows := tmuxRows(sessions)
+	if cursor < 0 || cursor >= len(rows) {
+		return nil, nil
+	}
+	session := &sessions[rows[cursor].session]
+	if rows[cursor].window < 0 {
+		return session, nil
+	}
+	return session, &session.Windows[rows[cursor].window]
+}
+
+// TotalTmuxOptions returns the total number of selectable options (sessions, their windows + new session)
 func TotalTmuxOptions(sessions []tmux.Session) int {
-	return len(sessions) + 1
+	return len(tmuxRows(sessions)) + 1
 }
 
 // IsNewSessionSelected returns true if the cursor is on the "New Session" option
 func IsNewSessionSelected(sessions []tmux.Session, cursor int) bool {
-	return cursor == len(sessions)
+	return cursor == len(tmuxRows(sessions))
 }
 
 // RenderTmuxConfirmDialog renders a confirmation dialog for tmux stop/restart operations
@@ -142,7 +259,234 @@ func RenderTmuxConfirmDialog(operation, sessionName string) string {
 	return renderConfirmDialog(operation, "tmux session", "Session", sessionName)
 }
 
+// RenderTmuxWindowConfirmDialog renders a confirmation dialog for killing a window
+func RenderTmuxWindowConfirmDialog(sessionName string, window tmux.Window) string {
+	return renderConfirmDialog("stop", "tmux window", "Window", sessionName+" "+window.FormatWindow())
+}
+
+// RenderTmuxNameInput renders the text input for naming a session or window
+func RenderTmuxNameInput(title, label, target, hint string, input textinput.Model) string {
+	b := renderWithHeader(title)
+	b.WriteString(label + ": ")
+	b.WriteString(SuccessStyle.Render(target))
+	b.WriteString("\n\n")
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	if hint != "" {
+		b.WriteString(DimmedStyle.Render(hint))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(HelpStyle.Render("Enter: Confirm  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderTmuxMoveWindow renders the list of sessions a window can be moved to
+func RenderTmuxMoveWindow(sessionName string, window tmux.Window, destinations []string, cursor int) string {
+	b := renderWithHeader("Move Window")
+	b.WriteString("Window: ")
+	b.WriteString(SuccessStyle.Render(sessionName + " " + window.FormatWindow()))
+	b.WriteString("\n\n")
+	b.WriteString("Move to session:")
+	b.WriteString("\n\n")
+	for i, dest := range destinations {
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(dest))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(dest))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("Enter: Move  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderTmuxUpdating renders progress while sessions and windows are being changed
+func RenderTmuxUpdating(sessionName, spinnerView string) string {
+	return renderOperation("Updating", "session", sessionName, spinnerView)
+}
+
+// RenderScrollbackExported lists the transcript files written for a session
+func RenderScrollbackExported(sessionName string, paths []string) string {
+	b := renderWithHeader("Scrollback Exported")
+	if len(paths) == 0 {
+		b.WriteString(DimmedStyle.Render("Session " + sessionName + " had no panes to export"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString("Session ")
+		b.WriteString(SuccessStyle.Render(sessionName))
+		b.WriteString(" written to:")
+		b.WriteString("\n\n")
+		for _, path := range paths {
+			b.WriteString("  " + path + "\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(HelpStyle.Render("Press any key to continue"))
+	return b.String()
+}
+
+// RenderRestoreSessions renders the offer to recreate the sessions saved before the
+// container stopped
+func RenderRestoreSessions(projectName string, saved *devcontainer.SavedSessions, now time.Time) string {
+	b := renderWithHeader("Restore Sessions")
+	b.WriteString("Project: ")
+	b.WriteString(SuccessStyle.Render(projectName))
+	b.WriteString("\n\n")
+	if saved == nil {
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("%d session(s) were running when the container stopped (%s):",
+		len(saved.Sessions), formatAge(saved.SavedAt, now)))
+	b.WriteString("\n\n")
+	for _, session := range saved.Sessions {
+		panes, agents := 0, 0
+		for _, w := range session.Windows {
+			for _, p := range w.Panes {
+				panes++
+				if p.Launch {
+					agents++
+				}
+			}
+		}
+		line := fmt.Sprintf("%s  %d window(s), %d pane(s)", session.Name, len(session.Windows), panes)
+		if agents > 0 {
+			line += fmt.Sprintf(", %d agent(s)", agents)
+		}
+		b.WriteString(NoCursor() + ItemStyle.Render(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(fmt.Sprintf("  %s  %s  %s  %s",
+		RenderKeyBinding("y", "restore"),
+		RenderKeyBinding("c", "restore + continue conversations"),
+		RenderKeyBinding("n", "discard"),
+		RenderKeyBinding("esc", "later"),
+	))
+	return b.String()
+}
+
+// RenderRestoringSessions renders progress while saved sessions are recreated
+func RenderRestoringSessions(projectName, spinnerView string) string {
+	return renderSpinnerAction(spinnerView, "Restoring sessions in", projectName)
+}
+
 // RenderTmuxOperation renders progress during tmux stop/restart operations
 func RenderTmuxOperation(operation, sessionName, spinnerView string) string {
 	return renderOperation(operation, "session", sessionName, spinnerView)
 }
+
+// agentBadge renders an agent state, prefixed with the number of sessions in it if more than one
+func agentBadge(state tmux.AgentState, count int) string {
+	label := state.String()
+	if count > 1 {
+		label = fmt.Sprintf("%d %s", count, label)
+	}
+	switch state {
+	case tmux.AgentWaiting:
+		return WarningStyle.Render("◆ " + label)
+	case tmux.AgentWorking:
+		return StatusInProgress.Render("◐ " + label)
+	case tmux.AgentIdle:
+		return StatusRunning.Render("◇ " + label)
+	default:
+		return DimmedStyle.Render("· " + label)
+	}
+}
+
+// mostUrgentAgent returns the agent state needing the most attention and how many
+// sessions are in it
+func mostUrgentAgent(states map[string]tmux.AgentState) (tmux.AgentState, int) {
+	urgent, count := tmux.AgentUnknown, 0
+	for _, state := range states {
+		switch {
+		case state > urgent:
+			urgent, count = state, 1
+		case state == urgent:
+			count++
+		}
+	}
+	return urgent, count
+}
+
+// RenderBroadcastLoading renders the loading state while listing sessions to broadcast to
+func RenderBroadcastLoading(spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Listing sessions", "", "Checking the agents in every running container...")
+}
+
+// RenderBroadcastSelect renders the sessions of all running containers, selectable
+// as targets for broadcast text
+func RenderBroadcastSelect(targets []sendTarget, selected map[int]bool, cursor int, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+	b.WriteString(RenderBorderedHeader("claude-quick", "Broadcast to Sessions", width))
+	b.WriteString("\n\n")
+
+	if len(targets) == 0 {
+		b.WriteString(DimmedStyle.Render("No tmux sessions in running containers."))
+		b.WriteString("\n\n")
+		b.WriteString(HelpStyle.Render("q/Esc: Back"))
+		return b.String()
+	}
+
+	for i, target := range targets {
+		checkbox := "[ ]"
+		if selected[i] {
+			checkbox = "[x]"
+		}
+		name := checkbox + " " + target.label()
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(name))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(name))
+		}
+		b.WriteString(" " + agentBadge(target.state, 1))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	keybindings := fmt.Sprintf("  %s  %s  %s  %s  %s",
+		RenderKeyBinding("space", "toggle"),
+		RenderKeyBinding("a", "all"),
+		RenderKeyBinding("w", "waiting"),
+		RenderKeyBinding("enter", fmt.Sprintf("send to %d", countSelected(selected))),
+		RenderKeyBinding("q", "back"),
+	)
+	b.WriteString(keybindings)
+	return b.String()
+}
+
+// RenderSendKeysInput renders the text input for text to type into sessions
+func RenderSendKeysInput(targets []string, input interface{ View() string }) string {
+	b := renderWithHeader("Send Keys")
+	if len(targets) == 1 {
+		b.WriteString("Session: ")
+		b.WriteString(SuccessStyle.Render(targets[0]))
+	} else {
+		b.WriteString(fmt.Sprintf("Sessions: %s", SuccessStyle.Render(fmt.Sprintf("%d selected", len(targets)))))
+		for _, target := range targets {
+			b.WriteString("\n  " + DimmedStyle.Render(target))
+		}
+	}
+	b.WriteString("\n\n")
+	b.WriteString("Text to type:")
+	b.WriteString("\n\n")
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("Typed literally into the active pane, followed by Enter."))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Send  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderSendingKeys renders the loading state while typing text into sessions
+func RenderSendingKeys(count int, spinnerView string) string {
+	return renderSpinnerAction(spinnerView, "Sending keys", fmt.Sprintf("%d session(s)", count))
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+// ============================================================================
+// tmux session template tests
+// ============================================================================
+
+func TestRenderNewSessionInput_Template(t *testing.T) {
+	ti := newTextInput("")
+	if view := RenderNewSessionInput("app", ti, "", false); strings.Contains(view, "Template") {
+		t.Error("template line should be hidden when no templates are configured")
+	}
+	view := RenderNewSessionInput("app", ti, "agent", true)
+	for _, want := range []string{"Template: ", "agent", "tab"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+	if view := RenderNewSessionInput("app", ti, "", true); !strings.Contains(view, "single window") {
+		t.Error("empty template should render as single window")
+	}
+}
+
+// ============================================================================
+// Pane preview tests
+// ============================================================================
+
+func TestRenderTmuxSelect_PanePreview(t *testing.T) {
+	sessions := []tmux.Session{{Name: "main"}, {Name: "tests"}}
+	panes := map[string]tmux.Pane{
+		"main":  {Session: "main", Command: "claude", Lines: []string{"Do you want to make this edit to main.go?"}},
+		"tests": {Session: "tests", Command: "bash", Lines: []string{"$ go test ./..."}},
+	}
+
+	view := RenderTmuxSelect("app", sessions, 0, "", panes, 160)
+	for _, want := range []string{"PREVIEW: main", "Do you want to make this edit", "waiting", "exited"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("wide view should contain %q", want)
+		}
+	}
+	if view := RenderTmuxSelect("app", sessions, 0, "", panes, 80); strings.Contains(view, "PREVIEW") {
+		t.Error("preview should be hidden when the terminal is too narrow")
+	}
+	if view := RenderTmuxSelect("app", sessions, len(sessions), "", panes, 160); strings.Contains(view, "PREVIEW") {
+		t.Error("preview should be hidden on the new session option")
+	}
+	if view := RenderTmuxSelect("app", sessions, 0, "", nil, 80); strings.Contains(view, "exited") {
+		t.Error("no badge should be shown before panes are captured")
+	}
+}
+
+func TestMostUrgentAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		states    map[string]tmux.AgentState
+		wantState tmux.AgentState
+		wantCount int
+	}{
+		{"no sessions", nil, tmux.AgentUnknown, 0},
+		{"waiting wins", map[string]tmux.AgentState{"a": tmux.AgentWorking, "b": tmux.AgentWaiting, "c": tmux.AgentIdle}, tmux.AgentWaiting, 1},
+		{"counts ties", map[string]tmux.AgentState{"a": tmux.AgentWorking, "b": tmux.AgentExited, "c": tmux.AgentWorking}, tmux.AgentWorking, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, count := mostUrgentAgent(tt.states)
+			if state != tt.wantState || count != tt.wantCount {
+				t.Errorf("mostUrgentAgent() = %v, %d, want %v, %d", state, count, tt.wantState, tt.wantCount)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Send keys tests
+// ============================================================================
+
+func TestRenderBroadcastSelect(t *testing.T) {
+	targets := []sendTarget{{
+		instance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app"}},
+		session:  "main",
+		state:    tmux.AgentWaiting,
+	}}
+	result := RenderBroadcastSelect(targets, map[int]bool{0: true}, 0, 80)
+	for _, want := range []string{"[x] app / main", "waiting", "send to 1"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("RenderBroadcastSelect() should contain %q", want)
+		}
+	}
+	if empty := RenderBroadcastSelect(nil, nil, 0, 80); !strings.Contains(empty, "No tmux sessions") {
+		t.Error("RenderBroadcastSelect() with no targets should say there are no sessions")
+	}
+}
+
+// ============================================================================
+// Tmux Window Management Tests
+// ============================================================================
+
+func windowedSessions() []tmux.Session {
+	return []tmux.Session{
+		{Name: "main", Windows: []tmux.Window{{Index: 0, Name: "claude", Active: true}, {Index: 1, Name: "shell"}}},
+		{Name: "logs"},
+	}
+}
+
+func TestTmuxRowAt(t *testing.T) {
+	sessions := windowedSessions()
+	tests := []struct {
+		cursor      int
+		wantSession string
+		wantWindow  int // -1 for a session row
+	}{
+		{0, "main", -1},
+		{1, "main", 0},
+		{2, "main", 1},
+		{3, "logs", -1},
+		{4, "", -1}, // New Session option
+	}
+	for _, tt := range tests {
+		session, window := tmuxRowAt(sessions, tt.cursor)
+		gotSession, gotWindow := "", -1
+		if session != nil {
+			gotSession = session.Name
+		}
+		if window != nil {
+			gotWindow = window.Index
+		}
+		if gotSession != tt.wantSession || gotWindow != tt.wantWindow {
+			t.Errorf("tmuxRowAt(%d) = (%q, %d), want (%q, %d)", tt.cursor, gotSession, gotWindow, tt.wantSession, tt.wantWindow)
+		}
+	}
+	if got := TotalTmuxOptions(sessions); got != 5 {
+		t.Errorf("TotalTmuxOptions() = %d, want 5", got)
+	}
+	if !IsNewSessionSelected(sessions, 4) {
+		t.Error("IsNewSessionSelected() should be true after the last window row")
+	}
+}
+
+func TestRenderTmuxSelect_Windows(t *testing.T) {
+	result := RenderTmuxSelect("app", windowedSessions(), 0, "", nil, 80)
+	for _, want := range []string{"├ 0: claude*", "└ 1: shell", "logs", "rename", "new window"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("RenderTmuxSelect() should contain %q", want)
+		}
+	}
+}
+
+// ============================================================================
+// Scrollback Export Tests
+// ============================================================================
+
+func TestRenderScrollbackExported_Empty(t *testing.T) {
+	if result := RenderScrollbackExported("main", nil); !strings.Contains(result, "no panes") {
+		t.Error("RenderScrollbackExported() with no files should say nothing was exported")
+	}
+}
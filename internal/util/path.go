@@ -2,6 +2,8 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
 	"path/filepath"
 )
@@ -26,3 +28,12 @@ func HomeDir() string {
 	}
 	return os.TempDir()
 }
+
+// PathKey returns the name state kept about a directory is stored under: its base
+// name plus a short hash of its full (cleaned) path, so worktrees with the same
+// directory name don't collide.
+func PathKey(path string) string {
+	path = filepath.Clean(path)
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Base(path) + "-" + hex.EncodeToString(sum[:4])
+}
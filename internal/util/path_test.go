@@ -108,3 +108,16 @@ func TestHomeDir(t *testing.T) {
 		t.Errorf("HomeDir() = %q, want temp dir %q", result, tempDir)
 	}
 }
+
+func TestPathKey(t *testing.T) {
+	key := PathKey("/work/repo-feature")
+	if !strings.HasPrefix(key, "repo-feature-") || len(key) != len("repo-feature-")+8 {
+		t.Errorf("PathKey() = %q, want the base name and an 8 digit hash", key)
+	}
+	if PathKey("/work/repo-feature/") != key {
+		t.Error("PathKey() should clean the path")
+	}
+	if PathKey("/other/repo-feature") == key {
+		t.Error("PathKey() should differ for directories with the same name")
+	}
+}
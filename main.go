@@ -45,7 +45,9 @@ func main() {
 	model := tui.NewWithDiscovery(cfg)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	model.Close() // Stop following docker events
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}